@@ -104,14 +104,13 @@ type Volume struct {
 	Label       Label
 	LatestIndex Index
 	Indexes     []struct {
-		Position tape.PositionData
+		Position tape.Position
 		Index    Index
 	}
 }
 
 // Open read LTFSVolume from drive
-// TODO interface
-func Open(drive *tape.Drive) (*Volume, error) {
+func Open(drive tape.Device) (*Volume, error) {
 	aVol1, aLabel, aIndex, err := readPartHead(drive, 0)
 	if err != nil {
 		panic(err)
@@ -132,9 +131,9 @@ func Open(drive *tape.Drive) (*Volume, error) {
 		//	panic(err)
 		//}
 		//status, err := drive.MTGetStatus()
-		dat, err := drive.MTReadShortFile()
+		dat, err := tape.ReadShortFile(drive)
 		if err != io.EOF {
-			if err := drive.MTBSFM(1); err != nil {
+			if err := bsfm(drive); err != nil {
 				panic(err)
 			}
 			log.Println(err)
@@ -143,9 +142,9 @@ func Open(drive *tape.Drive) (*Volume, error) {
 				return vol, nil
 			}
 			dat = make([]byte, 16<<20) // big buf!
-			n, err := drive.MTReadFull(dat)
+			n, err := tape.ReadFull(drive, dat)
 			if err != io.EOF {
-				if err := drive.MTBSFM(1); err != nil {
+				if err := bsfm(drive); err != nil {
 					panic(err)
 				}
 				return vol, fmt.Errorf("index too big")
@@ -158,9 +157,9 @@ func Open(drive *tape.Drive) (*Volume, error) {
 			return vol, err
 		}
 		vol.Indexes = append(vol.Indexes, struct {
-			Position tape.PositionData
+			Position tape.Position
 			Index    Index
-		}{Position: tape.PositionData{
+		}{Position: tape.Position{
 			Partition: 0,
 			Block:     0,
 			File:      0,
@@ -176,17 +175,22 @@ func PartToSCSIPart(c byte) int32 {
 }
 
 // readPartHead read and check partition has valid starting
-func readPartHead(drive *tape.Drive, part int32) (vol1, label, index []byte, err error) {
-	err = drive.MTSeek(0)
+func readPartHead(drive tape.Device, part int32) (vol1, label, index []byte, err error) {
+	err = drive.Locate(part, 0)
 	if err != nil {
 		panic(err)
 	}
-	err = drive.MTSwitchPart(part)
+	vol1 = tape.MustReadShortFile(drive)
+	label = tape.MustReadShortFile(drive)
+	index = tape.MustReadShortFile(drive)
+	return
+}
+
+// bsfm spaces backward over a filemark and positions after it, as MTBSFM does
+func bsfm(drive tape.Device) error {
+	err := drive.SpaceFilemarks(-1)
 	if err != nil {
-		panic(err)
+		return err
 	}
-	vol1 = drive.MTMustReadShortFile()
-	label = drive.MTMustReadShortFile()
-	index = drive.MTMustReadShortFile()
-	return
+	return drive.SpaceFilemarks(1)
 }
@@ -4,23 +4,56 @@ import (
 	"encoding/hex"
 	"log"
 	"os"
+	"strings"
 
 	"github.com/LXY1226/ltfswriter/ltfs"
 	"github.com/LXY1226/ltfswriter/tape"
 )
 
 func main() {
-	drive, err := tape.Open(os.Args[1])
+	path := os.Args[1]
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		// drive given by serial number or H:C:T:L
+		ds, err := tape.Discover()
+		if err != nil {
+			panic(err)
+		}
+		if p, ok := ds.Resolve(path); ok {
+			path = p
+		}
+	}
+	var dev tape.Device
+	var err error
+	switch {
+	case strings.HasSuffix(path, ".sgtrace"):
+		// reproduce a recorded session without the cartridge
+		dev, err = tape.OpenReplay(path)
+	case os.Getenv("LTFS_TRACE") != "":
+		tr, terr := tape.CreateTrace(os.Getenv("LTFS_TRACE"))
+		if terr != nil {
+			panic(terr)
+		}
+		defer tr.Close()
+		dev, err = tape.OpenWith(path, tape.OpenOptions{Direct: true, Trace: tr})
+	default:
+		dev, err = tape.OpenDevice(path)
+		if drive, ok := dev.(*tape.Drive); ok && err == nil {
+			err = drive.MTSetOptions(tape.MTSTBOOLEANS |
+				tape.MTSTBUFFERWRITES | tape.MTSTASYNCWRITES | tape.MTSTCANBSR | tape.MTSTCANPARTITIONS |
+				tape.MTSTNOWAITEOF | tape.MTSTSCSI2LOGICAL |
+				tape.MTSTDEBUGGING | tape.MTSTSILI | tape.MTSTSYSV)
+		}
+	}
 	if err != nil {
 		panic(err)
 	}
 
-	err = drive.MTSetOptions(tape.MTSTBOOLEANS |
-		tape.MTSTBUFFERWRITES | tape.MTSTASYNCWRITES | tape.MTSTCANBSR | tape.MTSTCANPARTITIONS |
-		tape.MTSTNOWAITEOF | tape.MTSTSCSI2LOGICAL |
-		tape.MTSTDEBUGGING | tape.MTSTSILI | tape.MTSTSYSV)
-	if err != nil {
-		panic(err)
+	if drive, ok := dev.(*tape.Drive); ok {
+		policy := tape.DefaultRecoveryPolicy
+		policy.Hook = func(e tape.RecoveryEvent) {
+			log.Printf("recovery: %v cmd=%x attempt=%d %v", e.Action, e.CDB, e.Attempt, e.Sense)
+		}
+		drive.SetRecoveryPolicy(policy)
 	}
 	//err = drive.MTSetOptions(tape.MTSTDEFBLKSIZE | 0xfffffff)
 	//if err != nil {
@@ -31,8 +64,13 @@ func main() {
 	//if err != nil {
 	//	panic(err)
 	//}
-	vol, err := ltfs.Open(drive)
+	vol, err := ltfs.Open(dev)
 	log.Println(err)
+	if drive, ok := dev.(*tape.Drive); ok {
+		if _, err := drive.LogTapeAlerts(); err != nil {
+			log.Println("TapeAlert:", err)
+		}
+	}
 	//if err != nil {
 	//	panic(err)
 	//}
@@ -62,7 +100,7 @@ func main() {
 	//		}
 	//	}
 	//}
-	//drive.CheckParts()
+	//drive.CheckParts(2)
 	//drive.DumpCapacity()
 	//drive.Locate10PartBlock(tape.Locate10FlagWithPart, 1, 1)
 	//pos, err := drive.ReadPosition()
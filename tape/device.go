@@ -0,0 +1,50 @@
+package tape
+
+import (
+	"errors"
+	"os"
+)
+
+var (
+	// ErrEOD indicates reading or spacing ran into the end of recorded data
+	ErrEOD = errors.New("end of data")
+	// ErrBOP indicates spacing backward ran into the beginning of partition
+	ErrBOP = errors.New("beginning of partition")
+)
+
+// Device is the set of sequential access operations used by ltfs and tools.
+// It is implemented by Drive and by VirtualTape.
+type Device interface {
+	// ReadBlock reads the next block into buf.
+	// Reading a filemark returns 0, io.EOF and positions after the filemark.
+	ReadBlock(buf []byte) (int, error)
+	// WriteBlock writes buf as one block at current position.
+	WriteBlock(buf []byte) error
+	// WriteFilemarks writes count filemarks at current position.
+	WriteFilemarks(count int32) error
+	// SpaceBlocks spaces over count blocks, backward if count is negative.
+	SpaceBlocks(count int32) error
+	// SpaceFilemarks spaces over count filemarks, backward if count is negative.
+	// Forward spacing ends after the last filemark, backward before it.
+	SpaceFilemarks(count int32) error
+	// Locate moves to logical block of partition.
+	Locate(part int32, block uint64) error
+	// ReadPosition reports the current position.
+	ReadPosition() (Position, error)
+	// SwitchPartition changes the active partition, returning to the block last
+	// visited in it, or its beginning if not visited since open.
+	SwitchPartition(part int32) error
+	Close() error
+}
+
+// OpenDevice opens path as VirtualTape if it is a directory, otherwise as Drive.
+func OpenDevice(path string) (Device, error) {
+	st, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if st.IsDir() {
+		return OpenVirtualTape(path)
+	}
+	return Open(path)
+}
@@ -0,0 +1,65 @@
+package tape
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Keystore holds named data encryption keys read from a local file.
+//
+// The file has one key per line as "<name> <hex key>", blank lines and lines starting
+// with # are ignored. It must not be accessible by group or others.
+// The name is written to tape as unauthenticated key-associated data, so the key of an
+// encrypted block can be found again by NextBlockEncryption.
+type Keystore struct {
+	path string
+	keys map[string][]byte
+}
+
+func LoadKeystore(path string) (*Keystore, error) {
+	st, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if st.Mode().Perm()&0o077 != 0 {
+		return nil, fmt.Errorf("keystore %s: mode %v is accessible by group or others", path, st.Mode().Perm())
+	}
+	dat, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	ks := &Keystore{path: path, keys: make(map[string][]byte)}
+	sc := bufio.NewScanner(bytes.NewReader(dat))
+	for line := 1; sc.Scan(); line++ {
+		s := strings.TrimSpace(sc.Text())
+		if s == "" || s[0] == '#' {
+			continue
+		}
+		fields := strings.Fields(s)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("keystore %s:%d: want <name> <hex key>", path, line)
+		}
+		key, err := hex.DecodeString(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("keystore %s:%d: %w", path, line, err)
+		}
+		if _, ok := ks.keys[fields[0]]; ok {
+			return nil, fmt.Errorf("keystore %s:%d: duplicate key %q", path, line, fields[0])
+		}
+		ks.keys[fields[0]] = key
+	}
+	return ks, sc.Err()
+}
+
+// Key returns the key called name
+func (ks *Keystore) Key(name string) ([]byte, error) {
+	key, ok := ks.keys[name]
+	if !ok {
+		return nil, fmt.Errorf("keystore %s: no key %q", ks.path, name)
+	}
+	return key, nil
+}
@@ -3,10 +3,10 @@
 package tape
 
 import (
+	"encoding/binary"
 	"errors"
-	"log"
-	"os/exec"
-	"strconv"
+	"fmt"
+	"os"
 	"strings"
 )
 
@@ -14,8 +14,11 @@ func NewMediaChanger(devPath string) *MediaChanger {
 	return &MediaChanger{devPath: devPath}
 }
 
+// MediaChanger drives a SCSI media changer (/dev/schX or its /dev/sgX) by SG_IO
 type MediaChanger struct {
 	devPath string
+	dev     *Drive
+	layout  *ElementLayout
 }
 
 type MediaChangerTape struct {
@@ -24,33 +27,75 @@ type MediaChangerTape struct {
 	Tag    string
 }
 
+// Element type codes of SMC
+const (
+	ElementAll          = 0
+	ElementTransport    = 1
+	ElementStorage      = 2
+	ElementImportExport = 3
+	ElementDataTransfer = 4
+)
+
+type ElementRange struct {
+	First uint16
+	Count uint16
+}
+
+func (r ElementRange) Contains(addr uint16) bool {
+	return addr >= r.First && addr < r.First+r.Count
+}
+
+// ElementLayout is ModePageElementAddress of the changer
+type ElementLayout struct {
+	Transport    ElementRange
+	Storage      ElementRange
+	ImportExport ElementRange
+	DataTransfer ElementRange
+}
+
+func (l ElementLayout) Range(typ byte) ElementRange {
+	switch typ {
+	case ElementTransport:
+		return l.Transport
+	case ElementStorage:
+		return l.Storage
+	case ElementImportExport:
+		return l.ImportExport
+	case ElementDataTransfer:
+		return l.DataTransfer
+	}
+	return ElementRange{}
+}
+
+type Element struct {
+	Type    byte
+	Address uint16
+	Full    bool
+	ImpExp  bool // placed by operator into import/export element
+	Except  bool // element is in abnormal state, see ASC/ASCQ
+	Access  bool // accessible by the transport
+	InEnab  bool // import/export element supports import
+	ExEnab  bool // import/export element supports export
+	ASC     byte
+	ASCQ    byte
+
+	SourceValid bool
+	Source      uint16 // element the medium was last moved from
+
+	VolumeTag string
+	DeviceID  string // DVCID of data transfer elements, the drive serial number
+}
+
 /*
+Slot numbering follows mtx, storage elements are counted from 1 and
+import/export elements continue after them, drives are counted from 0:
+
 root@pve:~# mtx -f /dev/sch0 status
   Storage Changer /dev/sch0:2 Drives, 24 Slots ( 1 Import/Export )
 Data Transfer Element 0:Full (Storage Element 22 Loaded):VolumeTag = 000057L5
 Data Transfer Element 1:Empty
       Storage Element 1:Empty
-      Storage Element 2:Empty
-      Storage Element 3:Empty
-      Storage Element 4:Empty
-      Storage Element 5:Empty
-      Storage Element 6:Empty
-      Storage Element 7:Empty
-      Storage Element 8:Empty
-      Storage Element 9:Empty
-      Storage Element 10:Empty
-      Storage Element 11:Empty
-      Storage Element 12:Empty
-      Storage Element 13:Full :VolumeTag=000052L5
-      Storage Element 14:Empty
-      Storage Element 15:Full :VolumeTag=000055L5
-      Storage Element 16:Full :VolumeTag=000054L5
-      Storage Element 17:Full :VolumeTag=000051L5
-      Storage Element 18:Empty
-      Storage Element 19:Empty
-      Storage Element 20:Full :VolumeTag=000053L5
-      Storage Element 21:Full :VolumeTag=000050L5
-      Storage Element 22:Empty
+      ...
       Storage Element 23:Empty
       Storage Element 24 IMPORT/EXPORT:Empty
 */
@@ -67,44 +112,276 @@ func in[T comparable](a T, arr []T) bool {
 	return false
 }
 
-func (sch *MediaChanger) GetLibraryInv(only ...string) ([]MediaChangerTape, error) {
-	cmd := exec.Command("mtx", "-f", sch.devPath, "status")
-	output, err := cmd.Output()
+func (sch *MediaChanger) open() (*Drive, error) {
+	if sch.dev != nil {
+		return sch.dev, nil
+	}
+	// not by Open, the changer takes none of the tape drive probes
+	f, err := os.OpenFile(sch.devPath, os.O_RDONLY, 0)
 	if err != nil {
 		return nil, err
 	}
-	var tapes []MediaChangerTape
-	for _, line := range strings.Split(string(output), "\n") {
-		if !strings.Contains(line, "Full") {
-			continue
+	sch.dev = &Drive{
+		File:  f,
+		fd:    f.Fd(),
+		state: &driveState{notTape: true},
+	}
+	return sch.dev, nil
+}
+
+func (sch *MediaChanger) Close() error {
+	if sch.dev == nil {
+		return nil
+	}
+	err := sch.dev.Close()
+	sch.dev = nil
+	return err
+}
+
+// Inquiry identifies the changer
+func (sch *MediaChanger) Inquiry() (InquiryData, error) {
+	dev, err := sch.open()
+	if err != nil {
+		return InquiryData{}, err
+	}
+	return dev.Inquiry()
+}
+
+// Layout reads the element address assignment once
+func (sch *MediaChanger) Layout() (ElementLayout, error) {
+	if sch.layout != nil {
+		return *sch.layout, nil
+	}
+	dev, err := sch.open()
+	if err != nil {
+		return ElementLayout{}, err
+	}
+	md, err := dev.ModeSense(ModePageElementAddress, 0, ModePCCurrent)
+	if err != nil {
+		return ElementLayout{}, err
+	}
+	body, err := pageBody(md.Page, ModePageElementAddress, 0)
+	if err != nil {
+		return ElementLayout{}, err
+	}
+	if len(body) < 16 {
+		return ElementLayout{}, fmt.Errorf("element address page: short page %d", len(body))
+	}
+	rng := func(b []byte) ElementRange {
+		return ElementRange{First: binary.BigEndian.Uint16(b), Count: binary.BigEndian.Uint16(b[2:])}
+	}
+	sch.layout = &ElementLayout{
+		Transport:    rng(body[0:]),
+		Storage:      rng(body[4:]),
+		ImportExport: rng(body[8:]),
+		DataTransfer: rng(body[12:]),
+	}
+	return *sch.layout, nil
+}
+
+func parseElementStatus(dat []byte) ([]Element, error) {
+	if len(dat) < 8 {
+		return nil, errors.New("read element status: short header")
+	}
+	if n := int(binary.BigEndian.Uint32(dat[4:])&0xffffff) + 8; n < len(dat) {
+		dat = dat[:n]
+	}
+	dat = dat[8:]
+	var elems []Element
+	for len(dat) >= 8 {
+		typ := dat[0]
+		pvoltag := dat[1]&0x80 != 0
+		avoltag := dat[1]&0x40 != 0
+		descLen := int(binary.BigEndian.Uint16(dat[2:]))
+		n := int(binary.BigEndian.Uint32(dat[4:]) & 0xffffff)
+		page := dat[8:min(len(dat), 8+n)]
+		dat = dat[8+len(page):]
+		if descLen < 12 {
+			return elems, fmt.Errorf("read element status: bad descriptor length %d", descLen)
 		}
-		tap := MediaChangerTape{Drive: -1}
-		i := strings.LastIndexByte(line, '=')
-		tap.Tag = strings.TrimSpace(line[i+1:])
-		if len(only) > 0 && !in(tap.Tag, only) {
-			continue
+		for ; len(page) >= descLen; page = page[descLen:] {
+			d := page[:descLen]
+			e := Element{
+				Type:        typ,
+				Address:     binary.BigEndian.Uint16(d),
+				Full:        d[2]&0x01 != 0,
+				ImpExp:      d[2]&0x02 != 0,
+				Except:      d[2]&0x04 != 0,
+				Access:      d[2]&0x08 != 0,
+				ExEnab:      d[2]&0x10 != 0,
+				InEnab:      d[2]&0x20 != 0,
+				ASC:         d[4],
+				ASCQ:        d[5],
+				SourceValid: d[9]&0x80 != 0,
+				Source:      binary.BigEndian.Uint16(d[10:]),
+			}
+			rest := d[12:]
+			if pvoltag && len(rest) >= 36 {
+				e.VolumeTag = strings.TrimRight(string(rest[:32]), " \x00")
+				rest = rest[36:]
+			}
+			if avoltag && len(rest) >= 36 {
+				rest = rest[36:]
+			}
+			if len(rest) >= 4 && int(rest[3]) > 0 && len(rest) >= 4+int(rest[3]) {
+				e.DeviceID = Designator{
+					CodeSet:    rest[0] & 0x0f,
+					Type:       rest[1] & 0x0f,
+					Identifier: rest[4 : 4+int(rest[3])],
+				}.String()
+			}
+			elems = append(elems, e)
 		}
+	}
+	return elems, nil
+}
 
-		elements := strings.Split(line, "Element ")
-		if len(elements) < 2 {
-			log.Print("invalid line from GetLibraryInv: ", line)
-			continue
-		}
+func (sch *MediaChanger) readElementStatus(typ byte, rng ElementRange, dvcid bool) ([]Element, error) {
+	dev, err := sch.open()
+	if err != nil {
+		return nil, err
+	}
+	recvLen := min(16+uint32(rng.Count)*256, 0xffffff)
+	var flags byte
+	if dvcid {
+		flags = 0x01
+	}
+	dat, err := dev.scsiRead([]byte{
+		ScsiOpReadElementStatus, 0x10 | typ&0x0f, // VOLTAG
+		byte(rng.First >> 8), byte(rng.First),
+		byte(rng.Count >> 8), byte(rng.Count),
+		flags,
+		byte(recvLen >> 16), byte(recvLen >> 8), byte(recvLen),
+		0, 0,
+	}, recvLen, 300_000)
+	if err != nil {
+		return nil, err
+	}
+	return parseElementStatus(dat)
+}
+
+// ReadElementStatus reads all elements of typ with volume tags,
+// data transfer elements also report their device identifier
+func (sch *MediaChanger) ReadElementStatus(typ byte) ([]Element, error) {
+	layout, err := sch.Layout()
+	if err != nil {
+		return nil, err
+	}
+	rng := layout.Range(typ)
+	if rng.Count == 0 {
+		return nil, nil
+	}
+	if typ != ElementDataTransfer {
+		return sch.readElementStatus(typ, rng, false)
+	}
+	elems, err := sch.readElementStatus(typ, rng, true)
+	if errors.Is(err, ErrIllegalRequest) {
+		// DVCID not supported
+		return sch.readElementStatus(typ, rng, false)
+	}
+	return elems, err
+}
 
-		i = strings.Index(elements[1], ":")
-		tap.SlotID, err = strconv.Atoi(elements[1][:i])
+// Inventory reads data transfer, storage and import/export elements
+func (sch *MediaChanger) Inventory() ([]Element, error) {
+	var all []Element
+	for _, typ := range []byte{ElementDataTransfer, ElementStorage, ElementImportExport} {
+		elems, err := sch.ReadElementStatus(typ)
 		if err != nil {
-			log.Print("invalid line from GetLibraryInv: ", line)
+			return nil, err
+		}
+		all = append(all, elems...)
+	}
+	return all, nil
+}
+
+// InitializeElementStatus makes the changer rescan all elements, like after opening the door
+func (sch *MediaChanger) InitializeElementStatus() error {
+	dev, err := sch.open()
+	if err != nil {
+		return err
+	}
+	return dev.scsiCmd([]byte{ScsiOpInitElementStatus, 0, 0, 0, 0, 0}, 1_800_000)
+}
+
+// MoveMedium moves medium between element addresses with the first transport element
+func (sch *MediaChanger) MoveMedium(src, dst uint16) error {
+	layout, err := sch.Layout()
+	if err != nil {
+		return err
+	}
+	dev, err := sch.open()
+	if err != nil {
+		return err
+	}
+	mte := layout.Transport.First
+	return dev.scsiCmd([]byte{
+		ScsiOpMoveMedium, 0,
+		byte(mte >> 8), byte(mte),
+		byte(src >> 8), byte(src),
+		byte(dst >> 8), byte(dst),
+		0, 0,
+		0, // INVERT
+		0,
+	}, 600_000)
+}
+
+// SlotAddress converts mtx slot number to element address
+func (l ElementLayout) SlotAddress(slot int) (uint16, error) {
+	switch {
+	case slot >= 1 && slot <= int(l.Storage.Count):
+		return l.Storage.First + uint16(slot-1), nil
+	case slot > int(l.Storage.Count) && slot <= int(l.Storage.Count)+int(l.ImportExport.Count):
+		return l.ImportExport.First + uint16(slot-1-int(l.Storage.Count)), nil
+	}
+	return 0, fmt.Errorf("slot %d out of range", slot)
+}
+
+// SlotNumber converts storage or import/export element address to mtx slot number
+func (l ElementLayout) SlotNumber(addr uint16) int {
+	switch {
+	case l.Storage.Contains(addr):
+		return int(addr-l.Storage.First) + 1
+	case l.ImportExport.Contains(addr):
+		return int(l.Storage.Count) + int(addr-l.ImportExport.First) + 1
+	}
+	return -1
+}
+
+// DriveAddress converts drive index to element address
+func (l ElementLayout) DriveAddress(driveID int) (uint16, error) {
+	if driveID < 0 || driveID >= int(l.DataTransfer.Count) {
+		return 0, fmt.Errorf("drive %d out of range", driveID)
+	}
+	return l.DataTransfer.First + uint16(driveID), nil
+}
+
+func (sch *MediaChanger) GetLibraryInv(only ...string) ([]MediaChangerTape, error) {
+	layout, err := sch.Layout()
+	if err != nil {
+		return nil, err
+	}
+	elems, err := sch.Inventory()
+	if err != nil {
+		return nil, err
+	}
+	var tapes []MediaChangerTape
+	for _, e := range elems {
+		if !e.Full {
+			continue
+		}
+		if len(only) > 0 && !in(e.VolumeTag, only) {
 			continue
 		}
-		if len(elements) > 2 {
-			tap.Drive = tap.SlotID
-			i = strings.Index(elements[2], " ")
-			tap.SlotID, err = strconv.Atoi(elements[2][:i])
-			if err != nil {
-				log.Print("invalid line from GetLibraryInv: ", line)
-				continue
+		tap := MediaChangerTape{Drive: -1, Tag: e.VolumeTag}
+		if e.Type == ElementDataTransfer {
+			tap.Drive = int(e.Address - layout.DataTransfer.First)
+			tap.SlotID = -1
+			if e.SourceValid {
+				tap.SlotID = layout.SlotNumber(e.Source)
 			}
+		} else {
+			tap.SlotID = layout.SlotNumber(e.Address)
 		}
 		tapes = append(tapes, tap)
 		if len(tapes) == len(only) {
@@ -115,13 +392,146 @@ func (sch *MediaChanger) GetLibraryInv(only ...string) ([]MediaChangerTape, erro
 }
 
 func (sch *MediaChanger) LoadTo(position int, driveID int) error {
-	return exec.Command("mtx", "-f", sch.devPath, "load", strconv.Itoa(position), "drive"+strconv.Itoa(driveID)).Run()
+	layout, err := sch.Layout()
+	if err != nil {
+		return err
+	}
+	src, err := layout.SlotAddress(position)
+	if err != nil {
+		return err
+	}
+	dst, err := layout.DriveAddress(driveID)
+	if err != nil {
+		return err
+	}
+	return sch.MoveMedium(src, dst)
 }
 
+// Unload returns the medium in drive to the slot it was loaded from
 func (sch *MediaChanger) Unload(driveID int) error {
-	return exec.Command("mtx", "-f", sch.devPath, "unload", "drive"+strconv.Itoa(driveID)).Run()
+	layout, err := sch.Layout()
+	if err != nil {
+		return err
+	}
+	src, err := layout.DriveAddress(driveID)
+	if err != nil {
+		return err
+	}
+	elems, err := sch.readElementStatus(ElementDataTransfer, ElementRange{First: src, Count: 1}, false)
+	if err != nil {
+		return err
+	}
+	if len(elems) == 0 || !elems[0].Full {
+		return fmt.Errorf("drive %d is empty", driveID)
+	}
+	if !elems[0].SourceValid {
+		return fmt.Errorf("drive %d: source slot unknown", driveID)
+	}
+	return sch.MoveMedium(src, elems[0].Source)
 }
 
+// UnloadTo moves the medium in drive to slot position, the medium must be ejected by the drive first
 func (sch *MediaChanger) UnloadTo(position int, driveID int) error {
-	panic("not implemented")
+	layout, err := sch.Layout()
+	if err != nil {
+		return err
+	}
+	src, err := layout.DriveAddress(driveID)
+	if err != nil {
+		return err
+	}
+	dst, err := layout.SlotAddress(position)
+	if err != nil {
+		return err
+	}
+	return sch.MoveMedium(src, dst)
+}
+
+var (
+	ErrMailslotFull = errors.New("no empty import/export element")
+	ErrNoFreeSlot   = errors.New("no empty storage element")
+)
+
+func firstEmpty(elems []Element, typ byte) (Element, bool) {
+	for _, e := range elems {
+		if e.Type == typ && !e.Full && e.Access && !e.Except {
+			return e, true
+		}
+	}
+	return Element{}, false
+}
+
+// Export moves medium tag from its storage slot or drive to an empty
+// import/export element and returns the mtx slot number of it.
+// A medium in a drive must be ejected by the drive first.
+func (sch *MediaChanger) Export(tag string) (int, error) {
+	layout, err := sch.Layout()
+	if err != nil {
+		return 0, err
+	}
+	elems, err := sch.Inventory()
+	if err != nil {
+		return 0, err
+	}
+	var src *Element
+	for i, e := range elems {
+		if e.Full && e.VolumeTag == tag {
+			src = &elems[i]
+			break
+		}
+	}
+	if src == nil {
+		return 0, ErrMediaNotFound
+	}
+	if src.Type == ElementImportExport {
+		return layout.SlotNumber(src.Address), nil
+	}
+	dst, ok := firstEmpty(elems, ElementImportExport)
+	if !ok {
+		return 0, ErrMailslotFull
+	}
+	err = sch.MoveMedium(src.Address, dst.Address)
+	if err != nil {
+		return 0, err
+	}
+	return layout.SlotNumber(dst.Address), nil
+}
+
+// Import moves media placed into import/export elements by the operator
+// to empty storage slots and returns them at their new slots.
+// Media exported by the changer itself are left in place.
+func (sch *MediaChanger) Import() ([]MediaChangerTape, error) {
+	layout, err := sch.Layout()
+	if err != nil {
+		return nil, err
+	}
+	elems, err := sch.Inventory()
+	if err != nil {
+		return nil, err
+	}
+	var imported []MediaChangerTape
+	for _, e := range elems {
+		if e.Type != ElementImportExport || !e.Full || !e.ImpExp {
+			continue
+		}
+		dst, ok := firstEmpty(elems, ElementStorage)
+		if !ok {
+			return imported, ErrNoFreeSlot
+		}
+		err = sch.MoveMedium(e.Address, dst.Address)
+		if err != nil {
+			return imported, fmt.Errorf("import %s: %w", e.VolumeTag, err)
+		}
+		for i := range elems {
+			if elems[i].Address == dst.Address {
+				elems[i].Full = true
+			}
+		}
+		imported = append(imported, MediaChangerTape{
+			SlotID: layout.SlotNumber(dst.Address),
+			Drive:  -1,
+			Tag:    e.VolumeTag,
+		})
+	}
+	return imported, nil
 }
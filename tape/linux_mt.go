@@ -3,14 +3,45 @@
 package tape
 
 import (
+	"fmt"
 	"os"
 	"unsafe"
 )
 
 type Drive struct {
-	*os.File           // File handle
-	fd         uintptr // File descriptor
-	fixedBlock bool    // Whether to use fixed block size
+	*os.File             // File handle
+	fd       uintptr     // File descriptor
+	direct   bool        // Data path by SG_IO instead of read/write on the st node
+	state    *driveState // Shared between copies of Drive
+}
+
+// driveState is what Drive learns about the device after open
+type driveState struct {
+	caps    *Capabilities
+	probing bool // caps being probed, commands of the probe take default timeouts
+	notTape bool // INQUIRY reported another device type, caps is not probed
+
+	blockSize      uint32 // fixed block length, 0 in variable block mode
+	blockSizeValid bool
+	blockSizeSet   bool // wantBlockSize was set by SetBlockSize and is restored after reset
+	wantBlockSize  uint32
+
+	policy     *RecoveryPolicy
+	recovering bool
+
+	pos       *Position         // cached by Tell, nil once the medium may have moved
+	partBlock map[uint32]uint64 // block last visited of each partition left by SwitchPartition
+
+	encryption      *EncryptionParams // set by SetEncryption, nil if both modes are off
+	encryptVerified bool              // the drive reported encryption active since
+
+	lbp    byte   // logical block protection method set by SetProtection
+	lbpBuf []byte // block with its CRC
+
+	reserveKey uint64 // of this host, set by Reserve or SetReservationKey
+
+	tracer *Tracer
+	replay *replay // serves SG_IO from a trace instead of the device
 }
 
 type MtOp struct {
@@ -90,7 +121,10 @@ func (d Drive) MTRAS3() error { return d.mtioctop(MTRAS3, 0) }
 func (d Drive) MTWEOFI(count int32) error { return d.mtioctop(MTWEOFI, count) }
 
 // MTSetBlock sets block length (SCSI)
-func (d Drive) MTSetBlock(size int32) error { return d.mtioctop(MTSETBLK, size) }
+func (d Drive) MTSetBlock(size int32) error {
+	d.state.blockSizeValid = false
+	return d.mtioctop(MTSETBLK, size)
+}
 
 // MTSetDensity sets tape density (SCSI)
 func (d Drive) MTSetDensity(density int32) error { return d.mtioctop(MTSETDENSITY, density) }
@@ -99,12 +133,10 @@ func (d Drive) MTSetDensity(density int32) error { return d.mtioctop(MTSETDENSIT
 func (d Drive) MTSeek(pos int32) error { return d.mtioctop(MTSEEK, pos) }
 
 // MTTell gets current block position (Tandberg, etc.)
+// st has no MTTELL operation, the block comes from MTIOCPOS
 func (d Drive) MTTell() (pos int32, err error) {
-	panic("not implemented")
-	// Note: MTTELL requires special handling, this is just a placeholder implementation
-	// Specific implementation needs to be adjusted according to driver documentation
-	err = d.mtioctop(MTTELL, 0)
-	return
+	p, err := d.MTGetPos()
+	return int32(p), err
 }
 
 // MTSetBuffer sets the drive buffering according to SCSI-2
@@ -155,8 +187,99 @@ func (d Drive) MTGetStatus() (status MtStatus, err error) {
 	return
 }
 
+// DriveState is MtStatus decoded
+type DriveState struct {
+	BOT             bool // at beginning of tape
+	EOF             bool // just passed a filemark
+	EOT             bool // past early warning
+	EOD             bool // at end of recorded data
+	WriteProtected  bool
+	Online          bool // tape loaded and ready
+	DoorOpen        bool // no tape
+	ImmediateReport bool
+	CleaningNeeded  bool
+
+	BlockSize uint32 // 0 in variable block mode
+	Density   byte
+
+	// File and Block are the file number and block within it as counted by st,
+	// valid only with PositionValid as st loses count after some operations
+	File          int32
+	Block         int32
+	PositionValid bool
+
+	Residual int64 // of the last operation
+}
+
+// Loaded reports whether a tape is in the drive and ready
+func (s DriveState) Loaded() bool { return s.Online && !s.DoorOpen }
+
+// Writable reports whether a tape is loaded and not write protected
+func (s DriveState) Writable() bool { return s.Loaded() && !s.WriteProtected }
+
+func (s DriveState) String() string {
+	str := "offline"
+	switch {
+	case s.DoorOpen:
+		str = "no tape"
+	case s.Online:
+		str = "online"
+	}
+	for _, f := range []struct {
+		set  bool
+		name string
+	}{
+		{s.BOT, "BOT"}, {s.EOF, "EOF"}, {s.EOT, "EOT"}, {s.EOD, "EOD"},
+		{s.WriteProtected, "WR_PROT"}, {s.ImmediateReport, "IM_REP_EN"}, {s.CleaningNeeded, "CLN"},
+	} {
+		if f.set {
+			str += " " + f.name
+		}
+	}
+	str += fmt.Sprintf(" blksize=%d density=%#02x", s.BlockSize, s.Density)
+	if s.PositionValid {
+		str += fmt.Sprintf(" file=%d block=%d", s.File, s.Block)
+	}
+	return str
+}
+
+// State decodes the status bits, DsReg and position of s
+func (s MtStatus) State() DriveState {
+	g := uint64(s.GStat)
+	return DriveState{
+		BOT:             g&GMTBOT != 0,
+		EOF:             g&GMTEOF != 0,
+		EOT:             g&GMTEOT != 0,
+		EOD:             g&GMTEOD != 0,
+		WriteProtected:  g&GMTWRPROT != 0,
+		Online:          g&GMTONLINE != 0,
+		DoorOpen:        g&GMTDROPEN != 0,
+		ImmediateReport: g&GMTIMREPEN != 0,
+		CleaningNeeded:  g&GMTCLN != 0,
+
+		BlockSize: uint32(s.DsReg&MTSTBLKSIZEMASK) >> MTSTBLKSIZESHIFT,
+		Density:   byte(s.DsReg & MTSTDENSITYMASK >> MTSTDENSITYSHIFT),
+
+		File:          s.FileNo,
+		Block:         s.BlkNo,
+		PositionValid: s.FileNo >= 0 && s.BlkNo >= 0,
+
+		Residual: s.ResID,
+	}
+}
+
+// State reads the status kept by st, sending no command except to flush buffered writes
+func (d Drive) State() (DriveState, error) {
+	status, err := d.MTGetStatus()
+	if err != nil {
+		return DriveState{}, err
+	}
+	return status.State(), nil
+}
+
 // mtioctop executes magnetic tape operation commands internally
 // op: operation type, count: operation count
 func (d Drive) mtioctop(op int16, count int32) (err error) {
-	return ioctl(d.fd, MTIOCTOP, uintptr(unsafe.Pointer(&MtOp{op: op, count: count})))
+	d.state.pos = nil
+	return d.stConflict(ioctl(d.fd, MTIOCTOP, uintptr(unsafe.Pointer(&MtOp{op: op, count: count}))))
 }
@@ -114,6 +114,21 @@ const (
 
 	MTISFTAPEFLAG = 0x800000 // QIC-40/80/3010/3020 ftape supported drives (20bit vendor ID + 0x800000)
 
+	// Generic status bits of MtStatus.GStat
+	GMTEOF     = 0x80000000 // Just passed a filemark
+	GMTBOT     = 0x40000000 // At beginning of tape
+	GMTEOT     = 0x20000000 // Past early warning
+	GMTSM      = 0x10000000 // Just passed a setmark
+	GMTEOD     = 0x08000000 // At end of recorded data
+	GMTWRPROT  = 0x04000000 // Write protected
+	GMTONLINE  = 0x01000000 // Tape loaded and ready
+	GMTD6250   = 0x00800000 // Density 6250 bpi
+	GMTD1600   = 0x00400000 // Density 1600 bpi
+	GMTD800    = 0x00200000 // Density 800 bpi
+	GMTDROPEN  = 0x00040000 // Door open, no tape
+	GMTIMREPEN = 0x00010000 // Immediate report mode
+	GMTCLN     = 0x00008000 // Cleaning requested
+
 	MTSTBLKSIZESHIFT = 0          // Blocksize shift
 	MTSTBLKSIZEMASK  = 0xffffff   // Blocksize mask
 	MTSTDENSITYSHIFT = 24         // Density shift
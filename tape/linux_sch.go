@@ -1,3 +1,118 @@
 //go:build linux
 
 package tape
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+)
+
+// LibraryDrive is a data transfer element of the changer matched to its device nodes
+type LibraryDrive struct {
+	Element int    // drive number as used by LoadTo and Unload
+	Address uint16 // element address
+	NstPath string // non-rewinding /dev/nstX
+	SgPath  string
+	Serial  string
+}
+
+var ErrDriveNotMapped = errors.New("drive not mapped to a device node")
+
+func nodeSerial(sgPath string) (string, error) {
+	d, err := Open(sgPath)
+	if err != nil {
+		return "", err
+	}
+	defer d.Close()
+	return d.SerialNumber()
+}
+
+// t10Serial returns the serial field of a T10 vendor ID designator, vendor in 8 bytes and
+// product in 16 followed by the serial, or the same with the padding collapsed
+func t10Serial(id string) string {
+	f := strings.Fields(id)
+	if len(f) == 0 {
+		return ""
+	}
+	last := f[len(f)-1]
+	if i := strings.LastIndex(id, last); i <= 8 && len(id) > 24 {
+		// a product of all 16 bytes runs into the serial
+		return strings.TrimSpace(id[24:])
+	}
+	return last
+}
+
+// matchDeviceID reports whether DVCID of a data transfer element identifies the drive of serial,
+// libraries report either the bare serial or a T10 vendor ID with it in the serial field
+func matchDeviceID(e Element, serial string) bool {
+	if serial == "" || e.DeviceID == "" {
+		return false
+	}
+	id := strings.TrimSpace(e.DeviceID)
+	if t10, ok := strings.CutPrefix(id, "t10."); ok {
+		id = t10Serial(t10)
+	}
+	return id == serial
+}
+
+// Drives maps data transfer elements to tape device nodes by DVCID and INQUIRY serial number.
+// Drives not found on this host are returned with empty NstPath and SgPath.
+func (sch *MediaChanger) Drives() ([]LibraryDrive, error) {
+	layout, err := sch.Layout()
+	if err != nil {
+		return nil, err
+	}
+	elems, err := sch.ReadElementStatus(ElementDataTransfer)
+	if err != nil {
+		return nil, err
+	}
+	ds, err := Discover()
+	if err != nil {
+		return nil, err
+	}
+	nodes := ds.Drives
+	for i, n := range nodes {
+		if n.Serial != "" || n.SgPath == "" {
+			continue
+		}
+		nodes[i].Serial, err = nodeSerial(n.SgPath)
+		if err != nil {
+			// likely a drive of another library, busy or offline, this one fails as unmapped
+			log.Printf("%s: reading serial: %v", n.SgPath, err)
+		}
+	}
+	drives := make([]LibraryDrive, len(elems))
+	for i, e := range elems {
+		ld := LibraryDrive{
+			Element: int(e.Address - layout.DataTransfer.First),
+			Address: e.Address,
+		}
+		for _, n := range nodes {
+			if matchDeviceID(e, n.Serial) {
+				ld.NstPath, ld.SgPath, ld.Serial = n.NstPath, n.SgPath, n.Serial
+				break
+			}
+		}
+		drives[i] = ld
+	}
+	return drives, nil
+}
+
+// Drive returns the device nodes of drive number driveID
+func (sch *MediaChanger) Drive(driveID int) (LibraryDrive, error) {
+	drives, err := sch.Drives()
+	if err != nil {
+		return LibraryDrive{}, err
+	}
+	for _, ld := range drives {
+		if ld.Element == driveID {
+			if ld.NstPath == "" {
+				return ld, fmt.Errorf("drive %d: %w", driveID, ErrDriveNotMapped)
+			}
+			return ld, nil
+		}
+	}
+	return LibraryDrive{}, fmt.Errorf("drive %d out of range", driveID)
+}
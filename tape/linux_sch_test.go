@@ -0,0 +1,26 @@
+//go:build linux
+
+package tape
+
+import "testing"
+
+func TestMatchDeviceID(t *testing.T) {
+	tests := []struct {
+		id, serial string
+		want       bool
+	}{
+		{"1068000123", "1068000123", true},
+		{"t10.IBM     ULT3580-TD8     1068000123", "1068000123", true},
+		{"t10.IBM     ULT3580-TD8     1068000123", "000123", false},
+		{"t10.HP      Ultrium 8-SCSI  HU1234ABCD", "HU1234ABCD", true},
+		{"t10.HP      ULTRIUM-HH8-SCSIHU1234ABCD", "HU1234ABCD", true},
+		{"t10.QUANTUM ULTRIUM-HH8 1234567890", "1234567890", true},
+		{"t10.QUANTUM ULTRIUM-HH8 1234567890", "567890", false},
+		{"", "1068000123", false},
+	}
+	for _, tt := range tests {
+		if got := matchDeviceID(Element{DeviceID: tt.id}, tt.serial); got != tt.want {
+			t.Errorf("matchDeviceID(%q, %q) = %v, want %v", tt.id, tt.serial, got, tt.want)
+		}
+	}
+}
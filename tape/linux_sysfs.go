@@ -0,0 +1,267 @@
+//go:build linux
+
+package tape
+
+import (
+	"cmp"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// SCSIAddress is H:C:T:L of a SCSI device
+type SCSIAddress struct {
+	Host, Channel, Target, LUN int
+}
+
+func (a SCSIAddress) String() string {
+	return fmt.Sprintf("%d:%d:%d:%d", a.Host, a.Channel, a.Target, a.LUN)
+}
+
+// SameTarget reports whether a and b are logical units of the same target
+func (a SCSIAddress) SameTarget(b SCSIAddress) bool {
+	return a.Host == b.Host && a.Channel == b.Channel && a.Target == b.Target
+}
+
+func (a SCSIAddress) compare(b SCSIAddress) int {
+	return cmp.Or(cmp.Compare(a.Host, b.Host), cmp.Compare(a.Channel, b.Channel),
+		cmp.Compare(a.Target, b.Target), cmp.Compare(a.LUN, b.LUN))
+}
+
+func ParseSCSIAddress(s string) (SCSIAddress, error) {
+	var a SCSIAddress
+	parts := strings.Split(s, ":")
+	if len(parts) != 4 {
+		return a, fmt.Errorf("scsi address %q: want H:C:T:L", s)
+	}
+	dst := []*int{&a.Host, &a.Channel, &a.Target, &a.LUN}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return a, fmt.Errorf("scsi address %q: %w", s, err)
+		}
+		*dst[i] = n
+	}
+	return a, nil
+}
+
+// DiscoveredDrive is a tape drive found in sysfs, paths are empty when the node does not exist
+type DiscoveredDrive struct {
+	Address  SCSIAddress
+	Vendor   string
+	Model    string
+	Revision string
+	Serial   string // from vpd_pg80, empty if the kernel does not export it
+
+	StPath  string
+	NstPath string
+	SgPath  string
+	Changer string // SchPath of the changer at the same target, if any
+}
+
+// DiscoveredChanger is a media changer found in sysfs
+type DiscoveredChanger struct {
+	Address  SCSIAddress
+	Vendor   string
+	Model    string
+	Revision string
+	Serial   string
+
+	SchPath string
+	SgPath  string
+}
+
+type Discovery struct {
+	Drives   []DiscoveredDrive
+	Changers []DiscoveredChanger
+}
+
+// DriveBySerial finds drive by INQUIRY serial number
+func (ds Discovery) DriveBySerial(serial string) (DiscoveredDrive, bool) {
+	for _, d := range ds.Drives {
+		if d.Serial != "" && d.Serial == serial {
+			return d, true
+		}
+	}
+	return DiscoveredDrive{}, false
+}
+
+func (ds Discovery) DriveByAddress(addr SCSIAddress) (DiscoveredDrive, bool) {
+	for _, d := range ds.Drives {
+		if d.Address == addr {
+			return d, true
+		}
+	}
+	return DiscoveredDrive{}, false
+}
+
+// DriveByPath finds drive by any of its st, nst or sg nodes
+func (ds Discovery) DriveByPath(path string) (DiscoveredDrive, bool) {
+	for _, d := range ds.Drives {
+		if path != "" && (d.StPath == path || d.NstPath == path || d.SgPath == path) {
+			return d, true
+		}
+	}
+	return DiscoveredDrive{}, false
+}
+
+// Discover lists tape drives and media changers of this host from /sys
+func Discover() (Discovery, error) {
+	return DiscoverAt("/sys")
+}
+
+var (
+	stName  = regexp.MustCompile(`^st\d+$`)
+	schName = regexp.MustCompile(`^sch\d+$`)
+)
+
+type sysfsDevice struct {
+	dir  string // .../H:C:T:L
+	typ  int
+	sg   string
+	st   string
+	sch  string
+	addr SCSIAddress
+}
+
+// DiscoverAt is Discover with sysfs mounted at root
+func DiscoverAt(root string) (Discovery, error) {
+	devs := make(map[SCSIAddress]*sysfsDevice)
+	get := func(class, name string) (*sysfsDevice, error) {
+		dir, err := filepath.EvalSymlinks(filepath.Join(root, "class", class, name, "device"))
+		if err != nil {
+			return nil, err
+		}
+		addr, err := ParseSCSIAddress(filepath.Base(dir))
+		if err != nil {
+			return nil, err
+		}
+		dev := devs[addr]
+		if dev == nil {
+			dev = &sysfsDevice{dir: dir, addr: addr, typ: -1}
+			if t, err := strconv.Atoi(sysfsAttr(dir, "type")); err == nil {
+				dev.typ = t
+			}
+			devs[addr] = dev
+		}
+		return dev, nil
+	}
+	walk := func(class string, match func(string) bool, set func(*sysfsDevice, string)) error {
+		ents, err := os.ReadDir(filepath.Join(root, "class", class))
+		if os.IsNotExist(err) {
+			return nil // driver not loaded
+		}
+		if err != nil {
+			return err
+		}
+		for _, ent := range ents {
+			if !match(ent.Name()) {
+				continue
+			}
+			dev, err := get(class, ent.Name())
+			if err != nil {
+				return err
+			}
+			set(dev, ent.Name())
+		}
+		return nil
+	}
+	err := walk("scsi_generic", func(string) bool { return true }, func(d *sysfsDevice, n string) { d.sg = n })
+	if err != nil {
+		return Discovery{}, err
+	}
+	err = walk("scsi_tape", stName.MatchString, func(d *sysfsDevice, n string) { d.st = n })
+	if err != nil {
+		return Discovery{}, err
+	}
+	err = walk("scsi_changer", schName.MatchString, func(d *sysfsDevice, n string) { d.sch = n })
+	if err != nil {
+		return Discovery{}, err
+	}
+
+	var ds Discovery
+	for _, dev := range devs {
+		switch {
+		case dev.st != "" || dev.typ == PeripheralSequentialAccess:
+			dd := DiscoveredDrive{
+				Address:  dev.addr,
+				Vendor:   sysfsAttr(dev.dir, "vendor"),
+				Model:    sysfsAttr(dev.dir, "model"),
+				Revision: sysfsAttr(dev.dir, "rev"),
+				Serial:   sysfsSerial(dev.dir),
+				SgPath:   devPath(dev.sg),
+			}
+			if dev.st != "" {
+				dd.StPath, dd.NstPath = devPath(dev.st), devPath("n"+dev.st)
+			}
+			ds.Drives = append(ds.Drives, dd)
+		case dev.sch != "" || dev.typ == PeripheralMediumChanger:
+			ds.Changers = append(ds.Changers, DiscoveredChanger{
+				Address:  dev.addr,
+				Vendor:   sysfsAttr(dev.dir, "vendor"),
+				Model:    sysfsAttr(dev.dir, "model"),
+				Revision: sysfsAttr(dev.dir, "rev"),
+				Serial:   sysfsSerial(dev.dir),
+				SchPath:  devPath(dev.sch),
+				SgPath:   devPath(dev.sg),
+			})
+		}
+	}
+	slices.SortFunc(ds.Drives, func(a, b DiscoveredDrive) int { return a.Address.compare(b.Address) })
+	slices.SortFunc(ds.Changers, func(a, b DiscoveredChanger) int { return a.Address.compare(b.Address) })
+	for i, d := range ds.Drives {
+		for _, c := range ds.Changers {
+			if c.Address.SameTarget(d.Address) {
+				ds.Drives[i].Changer = c.SchPath
+				break
+			}
+		}
+	}
+	return ds, nil
+}
+
+func devPath(name string) string {
+	if name == "" {
+		return ""
+	}
+	return "/dev/" + name
+}
+
+func sysfsAttr(dir, name string) string {
+	b, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(b))
+}
+
+// sysfsSerial reads the unit serial number VPD page cached by the kernel
+func sysfsSerial(dir string) string {
+	b, err := os.ReadFile(filepath.Join(dir, "vpd_pg80"))
+	if err != nil || len(b) < 4 {
+		return ""
+	}
+	n := min(int(binary.BigEndian.Uint16(b[2:])), len(b)-4)
+	return asciiField(b[4 : 4+n])
+}
+
+// Resolve finds the non-rewinding node of a drive given by serial number or H:C:T:L
+func (ds Discovery) Resolve(name string) (string, bool) {
+	d, ok := ds.DriveBySerial(name)
+	if !ok {
+		addr, err := ParseSCSIAddress(name)
+		if err != nil {
+			return "", false
+		}
+		d, ok = ds.DriveByAddress(addr)
+	}
+	if !ok || d.NstPath == "" {
+		return "", false
+	}
+	return d.NstPath, true
+}
@@ -0,0 +1,93 @@
+//go:build linux
+
+package tape
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiscoverAt(t *testing.T) {
+	ds, err := DiscoverAt("testdata/sysfs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Discovery{
+		Drives: []DiscoveredDrive{{
+			Address:  SCSIAddress{0, 0, 1, 0},
+			Vendor:   "IBM",
+			Model:    "ULT3580-TD8",
+			Revision: "Q3F4",
+			Serial:   "1068000123",
+			StPath:   "/dev/st0",
+			NstPath:  "/dev/nst0",
+			SgPath:   "/dev/sg0",
+			Changer:  "/dev/sch0",
+		}, {
+			Address:  SCSIAddress{0, 0, 2, 0},
+			Vendor:   "HP",
+			Model:    "Ultrium 8-SCSI",
+			Revision: "J4DB",
+			StPath:   "/dev/st1",
+			NstPath:  "/dev/nst1",
+			SgPath:   "/dev/sg2",
+		}},
+		Changers: []DiscoveredChanger{{
+			Address:  SCSIAddress{0, 0, 1, 1},
+			Vendor:   "IBM",
+			Model:    "3573-TL",
+			Revision: "F.11",
+			Serial:   "00L4U78B1234_LL0",
+			SchPath:  "/dev/sch0",
+			SgPath:   "/dev/sg1",
+		}},
+	}
+	if !reflect.DeepEqual(ds, want) {
+		t.Fatalf("DiscoverAt:\n got %+v\nwant %+v", ds, want)
+	}
+
+	for _, tt := range []struct {
+		name, path string
+		ok         bool
+	}{
+		{"1068000123", "/dev/nst0", true},
+		{"0:0:2:0", "/dev/nst1", true},
+		{"0:0:1:1", "", false}, // the changer
+		{"1:0:0:0", "", false}, // a disk
+		{"HU1234ABCD", "", false},
+		{"", "", false},
+	} {
+		path, ok := ds.Resolve(tt.name)
+		if path != tt.path || ok != tt.ok {
+			t.Errorf("Resolve(%q) = %q, %v, want %q, %v", tt.name, path, ok, tt.path, tt.ok)
+		}
+	}
+
+	for _, tt := range []struct {
+		path   string
+		serial string
+		ok     bool
+	}{
+		{"/dev/st0", "1068000123", true},
+		{"/dev/nst0", "1068000123", true},
+		{"/dev/sg0", "1068000123", true},
+		{"/dev/sg2", "", true},
+		{"/dev/sg1", "", false},
+		{"", "", false},
+	} {
+		d, ok := ds.DriveByPath(tt.path)
+		if d.Serial != tt.serial || ok != tt.ok {
+			t.Errorf("DriveByPath(%q) = %q, %v, want %q, %v", tt.path, d.Serial, ok, tt.serial, tt.ok)
+		}
+	}
+}
+
+func TestDiscoverAtEmpty(t *testing.T) {
+	ds, err := DiscoverAt(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ds.Drives) != 0 || len(ds.Changers) != 0 {
+		t.Fatalf("DiscoverAt of an empty sysfs: %+v", ds)
+	}
+}
@@ -1,27 +1,455 @@
 package tape
 
-import "os"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"syscall"
+	"time"
+	"unsafe"
 
-func (d Drive) WriteTo(wr *os.File) error {
-	ch := make(chan []byte)
-	var scsiError error
-	go func() {
-		for {
-			b, err := d.scsiReadData()
-			if err != nil {
-				scsiError = err
-				close(ch)
-				return
+	"github.com/ncw/directio"
+)
+
+// ErrNotSG is returned when streaming is asked on a node other than /dev/sgX,
+// the st driver would take the queued sg_io_hdr as data to write.
+var ErrNotSG = errors.New("streaming needs an sg device node")
+
+// ErrStreamProtected is returned when streaming is asked with logical block protection on,
+// use ReadBlock and WriteBlock which handle the CRC.
+var ErrStreamProtected = errors.New("streaming does not support logical block protection")
+
+const sgMajor = 21
+
+func isSG(fd uintptr) bool {
+	var st syscall.Stat_t
+	if syscall.Fstat(int(fd), &st) != nil {
+		return false
+	}
+	major := st.Rdev>>8&0xfff | st.Rdev>>32&^0xfff
+	return st.Mode&syscall.S_IFMT == syscall.S_IFCHR && major == sgMajor
+}
+
+// BufferPool recycles aligned buffers of the same size between commands
+type BufferPool struct {
+	size int
+	free chan []byte
+}
+
+// NewBufferPool keeps up to n idle buffers of size bytes
+func NewBufferPool(size, n int) *BufferPool {
+	return &BufferPool{size: size, free: make(chan []byte, n)}
+}
+
+func (p *BufferPool) Size() int { return p.size }
+
+func (p *BufferPool) Get() []byte {
+	select {
+	case b := <-p.free:
+		return b[:p.size]
+	default:
+		return directio.AlignedBlock(p.size)
+	}
+}
+
+func (p *BufferPool) Put(b []byte) {
+	if cap(b) < p.size {
+		return
+	}
+	select {
+	case p.free <- b[:p.size]:
+	default:
+	}
+}
+
+type StreamOptions struct {
+	Depth      int         // commands kept queued, 4 if 0
+	BufferSize int         // bytes per command, the block size written in variable block mode, 512 KiB if 0
+	Pool       *BufferPool // shared pool of BufferSize buffers, a private one if nil
+	// OnComplete is called with bytes transferred and the latency from submission of each command
+	OnComplete func(n int, latency time.Duration)
+}
+
+func (o StreamOptions) withDefaults() StreamOptions {
+	if o.Depth <= 0 {
+		o.Depth = 4
+	}
+	if o.Pool != nil {
+		o.BufferSize = o.Pool.Size()
+	}
+	if o.BufferSize <= 0 {
+		o.BufferSize = 512 << 10
+	}
+	if o.Pool == nil {
+		o.Pool = NewBufferPool(o.BufferSize, o.Depth+1)
+	}
+	return o
+}
+
+type StreamStats struct {
+	Commands int
+	Bytes    int64
+	Min      time.Duration
+	Max      time.Duration
+	Total    time.Duration
+}
+
+func (s StreamStats) Mean() time.Duration {
+	if s.Commands == 0 {
+		return 0
+	}
+	return s.Total / time.Duration(s.Commands)
+}
+
+func (s *StreamStats) add(n int, lat time.Duration) {
+	if s.Commands == 0 || lat < s.Min {
+		s.Min = lat
+	}
+	s.Max = max(s.Max, lat)
+	s.Commands++
+	s.Bytes += int64(n)
+	s.Total += lat
+}
+
+type sgRequest struct {
+	hdr   sgioHdr
+	buf   []byte
+	start time.Time
+	pin   runtime.Pinner
+}
+
+// sgQueue submits commands by write(2) on the sg node and reaps them in order by read(2)
+type sgQueue struct {
+	d          Drive
+	pending    []*sgRequest
+	nextID     int32
+	stats      StreamStats
+	onComplete func(int, time.Duration)
+}
+
+func (q *sgQueue) submit(cdb, buf []byte, dir int32) error {
+	r := &sgRequest{buf: buf}
+	r.hdr = newScsiCmd(cdb, q.d.cmdTimeout(cdb, rwTimeout))
+	r.hdr.dxferDirection = dir
+	r.hdr.dxferLen = uint32(len(buf))
+	r.hdr.dxferp = unsafe.Pointer(&buf[0])
+	r.hdr.packID = q.nextID
+	q.nextID++
+	q.d.state.pos = nil
+	// the driver keeps these until the command is read back
+	r.pin.Pin(r.hdr.dxferp)
+	r.pin.Pin(r.hdr.sbp)
+	r.start = time.Now()
+	_, err := syscall.Write(int(q.d.fd), unsafe.Slice((*byte)(unsafe.Pointer(&r.hdr)), unsafe.Sizeof(r.hdr)))
+	if err != nil {
+		r.pin.Unpin()
+		return err
+	}
+	q.pending = append(q.pending, r)
+	return nil
+}
+
+// reap waits for the oldest command and returns it with bytes transferred
+func (q *sgQueue) reap() (*sgRequest, int, error) {
+	r := q.pending[0]
+	q.pending = q.pending[1:]
+	var hdr sgioHdr
+	_, err := syscall.Read(int(q.d.fd), unsafe.Slice((*byte)(unsafe.Pointer(&hdr)), unsafe.Sizeof(hdr)))
+	lat := time.Since(r.start)
+	defer r.pin.Unpin()
+	if err != nil {
+		return r, 0, err
+	}
+	if t := q.d.state.tracer; t != nil {
+		t.record(&hdr, nil, lat)
+	}
+	if hdr.packID != r.hdr.packID {
+		return r, 0, fmt.Errorf("sg: completion %d out of order, want %d", hdr.packID, r.hdr.packID)
+	}
+	n := int(r.hdr.dxferLen) - int(hdr.resid)
+	if n < 0 || n > len(r.buf) {
+		n = 0
+	}
+	q.stats.add(n, lat)
+	if q.onComplete != nil {
+		q.onComplete(n, lat)
+	}
+	return r, n, hdrSense(&hdr)
+}
+
+// StreamReader reads the current file up to the next filemark with Depth READs queued
+type StreamReader struct {
+	q     sgQueue
+	pool  *BufferPool
+	depth int
+
+	cur    []byte // unread data of the last completed buffer
+	curBuf []byte
+	err    error // io.EOF at filemark
+	done   bool  // no more submissions
+
+	part    uint32
+	start   uint64 // logical object at open
+	objects uint64 // blocks and filemark consumed by the caller
+}
+
+// NewStreamReader starts streaming from the current position, d must be an sg node opened with Direct
+func (d Drive) NewStreamReader(opts StreamOptions) (*StreamReader, error) {
+	if !isSG(d.fd) {
+		return nil, ErrNotSG
+	}
+	if d.state.lbp != LBPNone {
+		return nil, ErrStreamProtected
+	}
+	opts = opts.withDefaults()
+	if _, err := d.BlockSize(); err != nil {
+		return nil, err
+	}
+	pos, err := d.ReadPosition()
+	if err != nil {
+		return nil, err
+	}
+	return &StreamReader{
+		q:     sgQueue{d: d, onComplete: opts.OnComplete},
+		pool:  opts.Pool,
+		depth: opts.Depth,
+		part:  pos.Partition,
+		start: pos.Block,
+	}, nil
+}
+
+func (r *StreamReader) fill() {
+	for !r.done && len(r.q.pending) < r.depth {
+		buf := r.pool.Get()
+		if bs := int(r.q.d.state.blockSize); bs != 0 {
+			buf = buf[:len(buf)/bs*bs]
+		}
+		cdb, err := r.q.d.rwCDB(ScsiOpRead, len(buf))
+		if err == nil {
+			err = r.q.submit(cdb, buf, sgDxferFromDev)
+		}
+		if err != nil {
+			r.pool.Put(buf)
+			r.done, r.err = true, err
+			return
+		}
+	}
+}
+
+// consumed counts logical objects passed by a completed READ
+func (r *StreamReader) consumed(n int, err error) uint64 {
+	var objs uint64
+	if bs := r.q.d.state.blockSize; bs != 0 {
+		objs = uint64(n) / uint64(bs)
+	} else if err == nil {
+		objs = 1
+	}
+	if errors.Is(err, ErrFilemark) {
+		objs++
+	}
+	return objs
+}
+
+func (r *StreamReader) Read(p []byte) (int, error) {
+	for len(r.cur) == 0 {
+		if r.curBuf != nil {
+			r.pool.Put(r.curBuf)
+			r.curBuf = nil
+		}
+		if r.err != nil {
+			return 0, r.err
+		}
+		r.fill()
+		if len(r.q.pending) == 0 {
+			return 0, r.err
+		}
+		req, n, err := r.q.reap()
+		r.cur, r.curBuf = req.buf[:n], req.buf
+		r.objects += r.consumed(n, err)
+		if err != nil {
+			r.done = true
+			if errors.Is(err, ErrFilemark) {
+				err = r.stopAtFilemark()
 			}
-			ch <- b
+			r.err = err
 		}
-	}()
-	for b := range ch {
-		_, err := wr.Write(b)
+	}
+	n := copy(p, r.cur)
+	r.cur = r.cur[n:]
+	return n, nil
+}
+
+// stopAtFilemark drains READs queued past the filemark and moves back to right after it
+func (r *StreamReader) stopAtFilemark() error {
+	if r.drain() {
+		err := r.q.d.LocatePartBlock(byte(r.part), r.start+r.objects)
 		if err != nil {
-			// TODO cancel
 			return err
 		}
 	}
-	return scsiError
+	return io.EOF
+}
+
+// drain waits for all queued commands, reporting whether any of them moved the medium
+func (r *StreamReader) drain() (moved bool) {
+	for len(r.q.pending) > 0 {
+		req, n, err := r.q.reap()
+		r.pool.Put(req.buf)
+		if n > 0 || errors.Is(err, ErrFilemark) {
+			moved = true
+		}
+	}
+	return moved
+}
+
+func (r *StreamReader) Stats() StreamStats { return r.q.stats }
+
+// Close waits for queued commands, the position is undefined unless Read returned io.EOF
+func (r *StreamReader) Close() error {
+	r.done = true
+	r.drain()
+	if r.curBuf != nil {
+		r.pool.Put(r.curBuf)
+		r.curBuf, r.cur = nil, nil
+	}
+	return nil
+}
+
+// StreamWriter writes BufferSize blocks with Depth WRITEs queued
+type StreamWriter struct {
+	q     sgQueue
+	pool  *BufferPool
+	depth int
+
+	buf []byte // being filled
+	n   int
+	err error
+
+	early  bool // a WRITE completed past early warning
+	warned bool // ErrEarlyWarning returned to the caller
+}
+
+// NewStreamWriter starts streaming at the current position, d must be an sg node opened with Direct and Write
+func (d Drive) NewStreamWriter(opts StreamOptions) (*StreamWriter, error) {
+	if !isSG(d.fd) {
+		return nil, ErrNotSG
+	}
+	if d.state.lbp != LBPNone {
+		return nil, ErrStreamProtected
+	}
+	opts = opts.withDefaults()
+	bs, err := d.BlockSize()
+	if err != nil {
+		return nil, err
+	}
+	if bs != 0 && opts.BufferSize%int(bs) != 0 {
+		return nil, fmt.Errorf("stream: buffer size %d is not a multiple of block size %d", opts.BufferSize, bs)
+	}
+	return &StreamWriter{
+		q:     sgQueue{d: d, onComplete: opts.OnComplete},
+		pool:  opts.Pool,
+		depth: opts.Depth,
+	}, nil
+}
+
+func (w *StreamWriter) complete(req *sgRequest, err error) {
+	w.pool.Put(req.buf)
+	err = earlyWarning(err)
+	switch {
+	case errors.Is(err, ErrEarlyWarning):
+		w.early = true
+	case err != nil && w.err == nil:
+		w.err = err
+	}
+}
+
+func (w *StreamWriter) submit() {
+	buf := w.buf[:w.n]
+	w.buf, w.n = nil, 0
+	err := w.q.d.requireEncryption()
+	var cdb []byte
+	if err == nil {
+		cdb, err = w.q.d.rwCDB(ScsiOpWrite, len(buf))
+	}
+	if err == nil {
+		err = w.q.submit(cdb, buf, sgDxferToDev)
+	}
+	if err != nil {
+		w.pool.Put(buf)
+		w.err = err
+		return
+	}
+	for len(w.q.pending) >= w.depth && w.err == nil {
+		req, _, err := w.q.reap()
+		w.complete(req, err)
+	}
+}
+
+// warning returns ErrEarlyWarning once after the medium passed early warning
+func (w *StreamWriter) warning() error {
+	if w.early && !w.warned {
+		w.warned = true
+		return ErrEarlyWarning
+	}
+	return nil
+}
+
+// Write queues p in BufferSize blocks. ErrEarlyWarning is returned once with all of p accepted,
+// writing may go on to finish the volume.
+func (w *StreamWriter) Write(p []byte) (int, error) {
+	written := 0
+	for len(p) > 0 {
+		if w.err != nil {
+			return written, w.err
+		}
+		if w.buf == nil {
+			w.buf = w.pool.Get()
+		}
+		c := copy(w.buf[w.n:], p)
+		w.n += c
+		p = p[c:]
+		written += c
+		if w.n == len(w.buf) {
+			w.submit()
+		}
+	}
+	if w.err != nil {
+		return written, w.err
+	}
+	return written, w.warning()
+}
+
+// Flush writes the partial block and waits for all queued WRITEs
+func (w *StreamWriter) Flush() error {
+	if w.n > 0 && w.err == nil {
+		w.submit()
+	}
+	for len(w.q.pending) > 0 {
+		req, _, err := w.q.reap()
+		w.complete(req, err)
+	}
+	if w.err != nil {
+		return w.err
+	}
+	return w.warning()
+}
+
+func (w *StreamWriter) Stats() StreamStats { return w.q.stats }
+
+// Close flushes the stream, filemarks are left to the caller
+func (w *StreamWriter) Close() error {
+	err := w.Flush()
+	if w.buf != nil {
+		w.pool.Put(w.buf)
+		w.buf = nil
+	}
+	return err
+}
+
+// WriteTo copies the current file up to the next filemark into wr
+func (d Drive) WriteTo(wr *os.File) error {
+	return d.WriteToContext(context.Background(), wr)
 }
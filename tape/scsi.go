@@ -3,15 +3,14 @@ package tape
 import (
 	"bytes"
 	"encoding/binary"
-	"encoding/hex"
-	util "github.com/LXY1226/ltfswriter/debug_util"
+	"errors"
+	"fmt"
 	"log"
+	"math"
 )
 
 const senseBufferSize = 32
 
-type senseError []byte
-
 var senseKeyDesc = [16]string{
 	0x00: "NO SENSE",
 	0x01: "RECOVERED ERROR",
@@ -30,67 +29,252 @@ var senseKeyDesc = [16]string{
 	0x0f: "COMPLETED",
 }
 
-func (s senseError) Error() string {
-	sb := new(bytes.Buffer)
-	sb.WriteString("SCSI sense error: ")
-	sb.WriteString(senseKeyDesc[s[2]])
-	sb.WriteString(" ")
-	b := hex.AppendEncode(sb.Bytes(), s[12:12]) // Additional Sense Code and Qualifier
-	b = append(b, '/')
-	b = hex.AppendEncode(b, s[13:13])
+const (
+	SenseKeyNoSense        = 0x00
+	SenseKeyRecoveredError = 0x01
+	SenseKeyNotReady       = 0x02
+	SenseKeyMediumError    = 0x03
+	SenseKeyHardwareError  = 0x04
+	SenseKeyIllegalRequest = 0x05
+	SenseKeyUnitAttention  = 0x06
+	SenseKeyDataProtect    = 0x07
+	SenseKeyBlankCheck     = 0x08
+	SenseKeyAbortedCommand = 0x0b
+	SenseKeyVolumeOverflow = 0x0d
+)
+
+// Sentinel errors matched by SenseError with errors.Is
+var (
+	ErrFilemark       = errors.New("filemark detected")
+	ErrEOM            = errors.New("end of medium")
+	ErrBlankCheck     = errors.New("blank check")
+	ErrNotReady       = errors.New("not ready")
+	ErrWriteProtected = errors.New("write protected")
+	ErrMediumError    = errors.New("medium error")
+	ErrHardwareError  = errors.New("hardware error")
+	ErrIllegalRequest = errors.New("illegal request")
+	ErrUnitAttention  = errors.New("unit attention")
+)
+
+// SenseError is decoded sense data returned with CHECK CONDITION
+type SenseError struct {
+	ResponseCode byte // 0x70/0x71 fixed, 0x72/0x73 descriptor format
+	Key          byte
+	ASC          byte
+	ASCQ         byte
+
+	InfoValid   bool
+	Information uint64 // residue for READ/WRITE/SPACE, see SSC
+
+	Filemark bool
+	EOM      bool
+	ILI      bool // incorrect length indicator
+
+	SKSValid bool
+	SKS      [3]byte // sense key specific bytes
+
+	Raw []byte
+}
+
+// ParseSense decodes fixed or descriptor format sense data
+func ParseSense(b []byte) (*SenseError, error) {
+	if len(b) < 1 {
+		return nil, errors.New("sense: empty")
+	}
+	s := &SenseError{ResponseCode: b[0] & 0x7f, Raw: bytes.Clone(b)}
+	switch s.ResponseCode {
+	case 0x70, 0x71:
+		if len(b) < 3 {
+			return nil, fmt.Errorf("sense: fixed format too short: %d", len(b))
+		}
+		s.Key = b[2] & 0x0f
+		s.Filemark = b[2]&0x80 != 0
+		s.EOM = b[2]&0x40 != 0
+		s.ILI = b[2]&0x20 != 0
+		if len(b) >= 7 {
+			s.InfoValid = b[0]&0x80 != 0
+			// signed, negative residues of SPACE backward and of ILI reads of larger blocks
+			s.Information = uint64(int64(int32(binary.BigEndian.Uint32(b[3:7]))))
+		}
+		if len(b) >= 14 {
+			s.ASC, s.ASCQ = b[12], b[13]
+		}
+		if len(b) >= 18 {
+			s.SKSValid = b[15]&0x80 != 0
+			copy(s.SKS[:], b[15:18])
+		}
+	case 0x72, 0x73:
+		if len(b) < 4 {
+			return nil, fmt.Errorf("sense: descriptor format too short: %d", len(b))
+		}
+		s.Key = b[1] & 0x0f
+		s.ASC, s.ASCQ = b[2], b[3]
+		if len(b) < 8 {
+			break
+		}
+		desc := b[8:min(len(b), 8+int(b[7]))]
+		for len(desc) >= 2 {
+			n := min(len(desc), 2+int(desc[1]))
+			d := desc[:n]
+			switch d[0] {
+			case 0x00: // information
+				if len(d) >= 12 {
+					s.InfoValid = d[2]&0x80 != 0
+					s.Information = binary.BigEndian.Uint64(d[4:12])
+				}
+			case 0x02: // sense key specific
+				if len(d) >= 7 {
+					s.SKSValid = d[4]&0x80 != 0
+					copy(s.SKS[:], d[4:7])
+				}
+			case 0x04: // stream commands
+				if len(d) >= 4 {
+					s.Filemark = d[3]&0x80 != 0
+					s.EOM = d[3]&0x40 != 0
+					s.ILI = d[3]&0x20 != 0
+				}
+			}
+			desc = desc[n:]
+		}
+	default:
+		return nil, fmt.Errorf("sense: unknown response code %#x", s.ResponseCode)
+	}
+	return s, nil
+}
+
+// Description returns the ASC/ASCQ text from SPC
+func (s *SenseError) Description() string {
+	return ascDescription(s.ASC, s.ASCQ)
+}
+
+func ascDescription(asc, ascq byte) string {
+	if d, ok := ascDesc[uint16(asc)<<8|uint16(ascq)]; ok {
+		return d
+	}
+	switch {
+	case asc == 0x40 && ascq >= 0x80:
+		return fmt.Sprintf("DIAGNOSTIC FAILURE ON COMPONENT %02XH", ascq)
+	case asc == 0x4d:
+		return fmt.Sprintf("TAGGED OVERLAPPED COMMANDS (TASK TAG %02XH)", ascq)
+	case asc == 0x70:
+		return fmt.Sprintf("DECOMPRESSION EXCEPTION SHORT ALGORITHM ID OF %02XH", ascq)
+	case asc >= 0x80 || ascq >= 0x80:
+		return "VENDOR SPECIFIC"
+	}
+	return "UNKNOWN"
+}
+
+// Progress returns the progress indication of a long operation in [0, 1]
+func (s *SenseError) Progress() (float64, bool) {
+	if !s.SKSValid || (s.Key != SenseKeyNotReady && s.Key != SenseKeyNoSense) {
+		return 0, false
+	}
+	return float64(binary.BigEndian.Uint16(s.SKS[1:])) / 65536, true
+}
+
+func (s *SenseError) Error() string {
+	b := []byte("SCSI sense error: ")
+	b = append(b, senseKeyDesc[s.Key]...)
+	b = fmt.Appendf(b, " %02x/%02x %s", s.ASC, s.ASCQ, s.Description())
+	if s.Filemark {
+		b = append(b, " [FILEMARK]"...)
+	}
+	if s.EOM {
+		b = append(b, " [EOM]"...)
+	}
+	if s.ILI {
+		b = append(b, " [ILI]"...)
+	}
+	if s.InfoValid {
+		b = fmt.Appendf(b, " info=%d", int64(s.Information))
+	}
+	if p, ok := s.Progress(); ok {
+		b = fmt.Appendf(b, " progress=%.1f%%", p*100)
+	}
 	return string(b)
 }
 
+func (s *SenseError) Is(target error) bool {
+	switch target {
+	case ErrFilemark:
+		return s.Filemark || (s.ASC == 0x00 && s.ASCQ == 0x01)
+	case ErrEOM:
+		return s.EOM || (s.ASC == 0x00 && s.ASCQ == 0x02) || s.Key == SenseKeyVolumeOverflow
+	case ErrBlankCheck:
+		return s.Key == SenseKeyBlankCheck
+	case ErrEOD:
+		return s.ASC == 0x00 && s.ASCQ == 0x05
+	case ErrBOP:
+		return s.ASC == 0x00 && s.ASCQ == 0x04
+	case ErrNotReady:
+		return s.Key == SenseKeyNotReady
+	case ErrWriteProtected:
+		return s.Key == SenseKeyDataProtect && s.ASC == 0x27
+	case ErrMediumError:
+		return s.Key == SenseKeyMediumError
+	case ErrHardwareError:
+		return s.Key == SenseKeyHardwareError
+	case ErrIllegalRequest:
+		return s.Key == SenseKeyIllegalRequest
+	case ErrUnitAttention:
+		return s.Key == SenseKeyUnitAttention
+	}
+	return false
+}
+
+// InProgress reports whether s tells a long operation is still running
+func (s *SenseError) InProgress() bool {
+	switch {
+	case s.Key == SenseKeyNotReady && s.ASC == 0x04:
+		switch s.ASCQ {
+		case 0x01, 0x04, 0x07, 0x09:
+			return true
+		}
+	case s.ASC == 0x00 && s.ASCQ >= 0x16 && s.ASCQ <= 0x1c:
+		return true
+	}
+	return false
+}
+
 func (d Drive) TestUnitReady() error {
-	d.scsiCmd([]byte{ScsiOpTestUnitReady, 0, 0, 0, 0, 0}, 60_000)
-	return nil
+	return d.scsiCmd([]byte{ScsiOpTestUnitReady, 0, 0, 0, 0, 0}, 60_000)
 }
 
-func (d Drive) CheckParts() {
-	dat, err := d.scsiRead([]byte{
-		ScsiOpModeSense6, 0,
-		ModePageMediumPartitions, 0,
-		0xff, 0, // bufLen
-	}, 0xff, 60_000)
+// RequestSense returns pending sense data, nil if there is nothing to report
+func (d Drive) RequestSense() (*SenseError, error) {
+	dat, err := d.scsiRead([]byte{ScsiOpRequestSense, 0, 0, 0, senseBufferSize, 0}, senseBufferSize, 60_000)
 	if err != nil {
-		panic(err)
+		return nil, err
+	}
+	s, err := ParseSense(dat)
+	if err != nil {
+		return nil, err
 	}
-	if len(dat) < 0xf {
-		log.Fatalln("CheckParts: short read", len(dat))
+	if s.Key == SenseKeyNoSense && s.ASC == 0 && s.ASCQ == 0 && !s.SKSValid {
+		return nil, nil
 	}
-	if dat[0xf] != 1 {
-		log.Fatalln("CheckParts: expected 2 partitions, got", dat[0xf]+1)
+	return s, nil
+}
+
+// CheckParts checks the medium has want partitions
+func (d Drive) CheckParts(want int) error {
+	var mp MediumPartitionPage
+	_, err := d.ReadModePage(&mp, ModePCCurrent)
+	if err != nil {
+		return err
 	}
+	if int(mp.AdditionalPartitions)+1 != want {
+		return fmt.Errorf("CheckParts: expected %d partitions, got %d", want, mp.AdditionalPartitions+1)
+	}
+	return nil
 }
 
 func (d Drive) DumpCapacity() {
-	//dat, err := d.scsiRead([]byte{
-	//	ScsiOpLogSense, 0,
-	//	LogPageSupportedPages, 0, 0,
-	//	0, 0, // ParameterPointer
-	//	0x0, 255, // bufLen
-	//	0}, 255, 60_000)
-	//if err != nil {
-	//	panic(err)
-	//}
-	//dumpHex(dat)
-	/*
-		00000000  00 00 00 15 00 02 03 0c  0d 11 12 13 14 15 16 17  |................|
-		00000010  1b 2e 30 31 32 33 34 35  3e 00 00 00 00 00 00 00  |..012345>.......|
-	*/
-	dat, err := d.scsiRead([]byte{
-		ScsiOpLogSense, 0,
-		LogPageTapeCapacity, 0, 0,
-		0, 0, // ParameterPointer
-		0x0, 0x80, // bufLen
-		0}, 0x80, 60_000)
+	c, err := d.Capacity()
 	if err != nil {
 		panic(err)
 	}
-	util.DumpHex(dat)
-
-	/*CurrentCumulativeValues*/
-	//0b01_000000 |
+	log.Printf("capacity: remaining %v MB, maximum %v MB\n", c.RemainingMB, c.MaximumMB)
 }
 
 func (d Drive) LocateBlock(block uint32) error {
@@ -134,36 +318,10 @@ func (d Drive) Locate16(flag byte, part byte, logicalID uint64) error {
 	return err
 }
 
-func (d Drive) scsiReadData() ([]byte, error) {
-	return d.scsiRead([]byte{
-		ScsiOpRead, 0b0000_0010, 0, 4, 00, 0,
-	}, 256*1024, 600_000)
-}
-
-type PositionData struct {
-	Partition uint32
-	Block     uint64
-	File      uint64
-}
-
-func (d Drive) ReadPosition() (PositionData, error) {
-	dat, err := d.scsiRead([]byte{
-		ScsiOpReadPosition, 6,
-		0, 0, 0, 0, 0, // reserved
-		0, 0, // must be 0
-		0,
-	}, 64, 60_000)
-	if err != nil {
-		return PositionData{}, err
-	}
-	// BOP
-	if dat[0]&0x80 == 0x80 {
-		return PositionData{Partition: binary.BigEndian.Uint32(dat[4:])}, nil
-	}
-	util.DumpHex(dat)
-	return PositionData{
-		Partition: binary.BigEndian.Uint32(dat[4:]),
-		Block:     binary.BigEndian.Uint64(dat[8:]),
-		File:      binary.BigEndian.Uint64(dat[16:]),
-	}, nil
+// LocatePartBlock locates block of part by LOCATE(16) if the drive supports it, LOCATE(10) otherwise
+func (d Drive) LocatePartBlock(part byte, block uint64) error {
+	if d.caps().Supports(ScsiOpLocate16, 0) || block > math.MaxUint32 {
+		return d.Locate16(Locate16FlagWithPart, part, block)
+	}
+	return d.Locate10PartBlock(Locate10FlagWithPart, part, uint32(block))
 }
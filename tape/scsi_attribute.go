@@ -0,0 +1,287 @@
+package tape
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Medium auxiliary memory attribute identifiers, see SPC-4 7.4
+const (
+	AttrRemainingCapacity   = 0x0000 // MiB remaining in partition
+	AttrMaximumCapacity     = 0x0001 // MiB maximum of partition
+	AttrTapeAlertFlags      = 0x0002
+	AttrLoadCount           = 0x0003
+	AttrMAMSpaceRemaining   = 0x0004
+	AttrAssigningOrg        = 0x0005
+	AttrFormatDensityCode   = 0x0006
+	AttrInitializationCount = 0x0007
+
+	AttrMediumManufacturer = 0x0400
+	AttrMediumSerial       = 0x0401
+	AttrMediumLength       = 0x0402
+	AttrMediumWidth        = 0x0403
+	AttrMediumDensityCode  = 0x0405
+	AttrMediumManufactured = 0x0406
+	AttrMediumType         = 0x0408
+
+	AttrApplicationVendor   = 0x0800
+	AttrApplicationName     = 0x0801
+	AttrApplicationVersion  = 0x0802
+	AttrUserMediumTextLabel = 0x0803
+	AttrLastWritten         = 0x0804
+	AttrBarcode             = 0x0806
+	AttrOwningHost          = 0x0807
+	AttrMediaPool           = 0x0808
+	AttrVolumeCoherencyInfo = 0x080C
+)
+
+const (
+	AttrFormatBinary = 0b00
+	AttrFormatASCII  = 0b01
+	AttrFormatText   = 0b10
+)
+
+type Attribute struct {
+	ID       uint16
+	ReadOnly bool
+	Format   byte
+	Value    []byte
+}
+
+// String returns ASCII and text attributes with trailing padding removed
+func (a Attribute) String() string {
+	return strings.TrimRight(string(a.Value), " \x00")
+}
+
+// Uint returns binary attributes of up to 8 bytes as integer
+func (a Attribute) Uint() uint64 {
+	var v uint64
+	for _, b := range a.Value {
+		v = v<<8 | uint64(b)
+	}
+	return v
+}
+
+// NewASCIIAttribute pads s with spaces to size as required for ASCII attributes
+func NewASCIIAttribute(id uint16, s string, size int) Attribute {
+	v := bytes.Repeat([]byte{' '}, size)
+	copy(v, s)
+	return Attribute{ID: id, Format: AttrFormatASCII, Value: v}
+}
+
+// NewTextAttribute pads s with NUL to size
+func NewTextAttribute(id uint16, s string, size int) Attribute {
+	v := make([]byte, size)
+	copy(v, s)
+	return Attribute{ID: id, Format: AttrFormatText, Value: v}
+}
+
+func parseAttributes(dat []byte) ([]Attribute, error) {
+	if len(dat) < 4 {
+		return nil, errors.New("attribute: short header")
+	}
+	n := binary.BigEndian.Uint32(dat)
+	dat = dat[4:]
+	if int(n) < len(dat) {
+		dat = dat[:n]
+	}
+	var attrs []Attribute
+	for len(dat) >= 5 {
+		l := int(binary.BigEndian.Uint16(dat[3:]))
+		if len(dat) < 5+l {
+			return attrs, fmt.Errorf("attribute %#04x: truncated", binary.BigEndian.Uint16(dat))
+		}
+		attrs = append(attrs, Attribute{
+			ID:       binary.BigEndian.Uint16(dat),
+			ReadOnly: dat[2]&0x80 != 0,
+			Format:   dat[2] & 0b11,
+			Value:    dat[5 : 5+l],
+		})
+		dat = dat[5+l:]
+	}
+	return attrs, nil
+}
+
+// ReadAttributes reads attribute values of partition starting from attribute first
+func (d Drive) ReadAttributes(part byte, first uint16) ([]Attribute, error) {
+	recvLen := uint32(4096)
+	for {
+		dat, err := d.scsiRead([]byte{
+			ScsiOpReadAttribute, 0x00, // ATTRIBUTE VALUES
+			0, 0, 0,
+			0, // logical volume
+			0,
+			part,
+			byte(first >> 8), byte(first),
+			byte(recvLen >> 24), byte(recvLen >> 16), byte(recvLen >> 8), byte(recvLen),
+			0, 0,
+		}, recvLen, 60_000)
+		if err != nil {
+			return nil, err
+		}
+		if len(dat) >= 4 {
+			if n := binary.BigEndian.Uint32(dat) + 4; n > recvLen {
+				recvLen = n
+				continue
+			}
+		}
+		return parseAttributes(dat)
+	}
+}
+
+// ReadAttribute reads single attribute id of partition
+func (d Drive) ReadAttribute(part byte, id uint16) (Attribute, error) {
+	attrs, err := d.ReadAttributes(part, id)
+	if err != nil {
+		return Attribute{}, err
+	}
+	for _, a := range attrs {
+		if a.ID == id {
+			return a, nil
+		}
+	}
+	return Attribute{}, fmt.Errorf("attribute %#04x not present", id)
+}
+
+// WriteAttribute writes attrs to partition, a zero length Value deletes the attribute
+func (d Drive) WriteAttribute(part byte, attrs ...Attribute) error {
+	buf := make([]byte, 4)
+	for _, a := range attrs {
+		buf = binary.BigEndian.AppendUint16(buf, a.ID)
+		buf = append(buf, a.Format&0b11)
+		buf = binary.BigEndian.AppendUint16(buf, uint16(len(a.Value)))
+		buf = append(buf, a.Value...)
+	}
+	binary.BigEndian.PutUint32(buf, uint32(len(buf)-4))
+	n := len(buf)
+	return d.scsiWrite([]byte{
+		ScsiOpWriteAttribute, 0x01, // WTC
+		0, 0, 0,
+		0, // logical volume
+		0,
+		part,
+		0, 0,
+		byte(n >> 24), byte(n >> 16), byte(n >> 8), byte(n),
+		0, 0,
+	}, buf, 60_000)
+}
+
+// VolumeCoherency is the LTFS Volume Coherency Information attribute
+type VolumeCoherency struct {
+	VolumeChangeRef uint64
+	Generation      uint64 // generation number of the index
+	BlockAddress    uint64 // position of the index in the partition
+	// Application client specific information, "LTFS" for LTFS volumes
+	Application string
+	VolumeUUID  string
+	Version     byte
+}
+
+func ParseVolumeCoherency(v []byte) (vci VolumeCoherency, err error) {
+	if len(v) < 1 {
+		return vci, errors.New("vci: empty")
+	}
+	l := int(v[0])
+	if len(v) < 1+l+8+8+2 {
+		return vci, errors.New("vci: too short")
+	}
+	for _, b := range v[1 : 1+l] {
+		vci.VolumeChangeRef = vci.VolumeChangeRef<<8 | uint64(b)
+	}
+	v = v[1+l:]
+	vci.Generation = binary.BigEndian.Uint64(v)
+	vci.BlockAddress = binary.BigEndian.Uint64(v[8:])
+	info := v[18:]
+	if n := int(binary.BigEndian.Uint16(v[16:])); n < len(info) {
+		info = info[:n]
+	}
+	// "LTFS\0" + UUID "\0" terminated in 37 bytes + version
+	if len(info) >= 5+37+1 && string(info[:5]) == "LTFS\x00" {
+		vci.Application = "LTFS"
+		vci.VolumeUUID = string(bytes.TrimRight(info[5:42], "\x00"))
+		vci.Version = info[42]
+	} else {
+		vci.Application = string(bytes.TrimRight(info, "\x00"))
+	}
+	return vci, nil
+}
+
+func (vci VolumeCoherency) Attribute() Attribute {
+	v := []byte{8}
+	v = binary.BigEndian.AppendUint64(v, vci.VolumeChangeRef)
+	v = binary.BigEndian.AppendUint64(v, vci.Generation)
+	v = binary.BigEndian.AppendUint64(v, vci.BlockAddress)
+	var info []byte
+	if vci.Application == "LTFS" {
+		info = append([]byte("LTFS\x00"), make([]byte, 37)...)
+		copy(info[5:41], vci.VolumeUUID)
+		info = append(info, vci.Version)
+	} else {
+		info = []byte(vci.Application)
+	}
+	v = binary.BigEndian.AppendUint16(v, uint16(len(info)))
+	v = append(v, info...)
+	return Attribute{ID: AttrVolumeCoherencyInfo, Format: AttrFormatBinary, Value: v}
+}
+
+// MAM is the decoded set of commonly used medium auxiliary memory attributes
+type MAM struct {
+	RemainingCapacityMB uint64
+	MaximumCapacityMB   uint64
+	LoadCount           uint64
+
+	Manufacturer string
+	MediumSerial string
+	Barcode      string
+
+	ApplicationVendor  string
+	ApplicationName    string
+	ApplicationVersion string
+	UserLabel          string
+
+	VolumeCoherency *VolumeCoherency
+}
+
+func DecodeMAM(attrs []Attribute) (m MAM) {
+	for _, a := range attrs {
+		switch a.ID {
+		case AttrRemainingCapacity:
+			m.RemainingCapacityMB = a.Uint()
+		case AttrMaximumCapacity:
+			m.MaximumCapacityMB = a.Uint()
+		case AttrLoadCount:
+			m.LoadCount = a.Uint()
+		case AttrMediumManufacturer:
+			m.Manufacturer = a.String()
+		case AttrMediumSerial:
+			m.MediumSerial = a.String()
+		case AttrBarcode:
+			m.Barcode = a.String()
+		case AttrApplicationVendor:
+			m.ApplicationVendor = a.String()
+		case AttrApplicationName:
+			m.ApplicationName = a.String()
+		case AttrApplicationVersion:
+			m.ApplicationVersion = a.String()
+		case AttrUserMediumTextLabel:
+			m.UserLabel = a.String()
+		case AttrVolumeCoherencyInfo:
+			if vci, err := ParseVolumeCoherency(a.Value); err == nil {
+				m.VolumeCoherency = &vci
+			}
+		}
+	}
+	return
+}
+
+// ReadMAM reads and decodes all attributes of partition
+func (d Drive) ReadMAM(part byte) (MAM, error) {
+	attrs, err := d.ReadAttributes(part, 0)
+	if err != nil {
+		return MAM{}, err
+	}
+	return DecodeMAM(attrs), nil
+}
@@ -0,0 +1,196 @@
+package tape
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+)
+
+type CommandInfo struct {
+	Opcode           byte
+	ServiceAction    uint16
+	HasServiceAction bool
+	CDBLength        uint16
+	// Zero when the drive has no timeout descriptor for the command
+	NominalTimeout     time.Duration
+	RecommendedTimeout time.Duration
+}
+
+// Capabilities is what the drive reports about itself by REPORT SUPPORTED
+// OPERATION CODES and READ BLOCK LIMITS
+type Capabilities struct {
+	// Commands is nil if REPORT SUPPORTED OPERATION CODES is not supported
+	Commands map[uint32]CommandInfo
+
+	Granularity    byte // block length must be a multiple of 2^Granularity
+	MaxBlockLength uint32
+	MinBlockLength uint16
+}
+
+func commandKey(op byte, sa uint16, hasSA bool) uint32 {
+	if !hasSA {
+		return uint32(op) << 24
+	}
+	return uint32(op)<<24 | 1<<16 | uint32(sa)
+}
+
+// Command looks up op, or op with service action sa if the command has one
+func (c *Capabilities) Command(op byte, sa uint16) (CommandInfo, bool) {
+	if ci, ok := c.Commands[commandKey(op, 0, false)]; ok {
+		return ci, true
+	}
+	ci, ok := c.Commands[commandKey(op, sa, true)]
+	return ci, ok
+}
+
+// Supports reports whether op/sa is supported. Not probed (nil) is unknown and reports
+// false so callers take the conservative command, a drive without REPORT SUPPORTED
+// OPERATION CODES is assumed to support it.
+func (c *Capabilities) Supports(op byte, sa uint16) bool {
+	if c == nil {
+		return false
+	}
+	if c.Commands == nil {
+		return true
+	}
+	_, ok := c.Command(op, sa)
+	return ok
+}
+
+// Timeout returns the recommended timeout of op/sa in ms, def if unknown
+func (c *Capabilities) Timeout(op byte, sa uint16, def uint32) uint32 {
+	if c == nil {
+		return def
+	}
+	ci, ok := c.Command(op, sa)
+	if !ok {
+		return def
+	}
+	t := ci.RecommendedTimeout
+	if t == 0 {
+		t = ci.NominalTimeout
+	}
+	if t == 0 {
+		return def
+	}
+	return uint32(t.Milliseconds())
+}
+
+func parseSupportedOps(dat []byte) (map[uint32]CommandInfo, error) {
+	if len(dat) < 4 {
+		return nil, errors.New("report supported op: short header")
+	}
+	if n := int(binary.BigEndian.Uint32(dat)) + 4; n < len(dat) {
+		dat = dat[:n]
+	}
+	dat = dat[4:]
+	cmds := make(map[uint32]CommandInfo)
+	for len(dat) >= 8 {
+		ci := CommandInfo{
+			Opcode:           dat[0],
+			ServiceAction:    binary.BigEndian.Uint16(dat[2:]),
+			HasServiceAction: dat[5]&0x01 != 0,
+			CDBLength:        binary.BigEndian.Uint16(dat[6:]),
+		}
+		ctdp := dat[5]&0x02 != 0
+		dat = dat[8:]
+		if ctdp {
+			if len(dat) < 12 {
+				return cmds, fmt.Errorf("report supported op: truncated timeouts of %#02x", ci.Opcode)
+			}
+			ci.NominalTimeout = time.Duration(binary.BigEndian.Uint32(dat[4:])) * time.Second
+			ci.RecommendedTimeout = time.Duration(binary.BigEndian.Uint32(dat[8:])) * time.Second
+			dat = dat[12:]
+		}
+		cmds[commandKey(ci.Opcode, ci.ServiceAction, ci.HasServiceAction)] = ci
+	}
+	return cmds, nil
+}
+
+func (d Drive) reportSupportedOps() (map[uint32]CommandInfo, error) {
+	recvLen := uint32(8192)
+	for {
+		dat, err := d.scsiRead([]byte{
+			byte(ScsiOpReportSupportedOp >> 8), byte(ScsiOpReportSupportedOp & 0x1f),
+			0x80, // RCTD, all commands
+			0, 0, 0,
+			byte(recvLen >> 24), byte(recvLen >> 16), byte(recvLen >> 8), byte(recvLen),
+			0, 0,
+		}, recvLen, 60_000)
+		if err != nil {
+			return nil, err
+		}
+		if len(dat) >= 4 {
+			if n := binary.BigEndian.Uint32(dat) + 4; n > recvLen {
+				recvLen = n
+				continue
+			}
+		}
+		return parseSupportedOps(dat)
+	}
+}
+
+// ReadBlockLimits returns granularity, maximum and minimum block length
+func (d Drive) ReadBlockLimits() (gran byte, maxLen uint32, minLen uint16, err error) {
+	dat, err := d.scsiRead([]byte{ScsiOpReadBlockLimits, 0, 0, 0, 0, 0}, 6, 60_000)
+	if err != nil {
+		return
+	}
+	if len(dat) < 6 {
+		err = fmt.Errorf("read block limits: short data %d", len(dat))
+		return
+	}
+	return dat[0] & 0x1f, binary.BigEndian.Uint32(dat) & 0xffffff, binary.BigEndian.Uint16(dat[4:]), nil
+}
+
+// Capabilities probes the drive once and caches the result for timeouts and command selection
+func (d Drive) Capabilities() (*Capabilities, error) {
+	if d.state.caps != nil {
+		return d.state.caps, nil
+	}
+	caps := new(Capabilities)
+	var err error
+	caps.Commands, err = d.reportSupportedOps()
+	if err != nil && !errors.Is(err, ErrIllegalRequest) {
+		return nil, err
+	}
+	caps.Granularity, caps.MaxBlockLength, caps.MinBlockLength, err = d.ReadBlockLimits()
+	if err != nil {
+		return nil, err
+	}
+	d.state.caps = caps
+	return caps, nil
+}
+
+// caps returns the capabilities of a tape drive, probed on first use. It is nil for other
+// devices, and while the probe fails, which is tried again with the next command.
+func (d Drive) caps() *Capabilities {
+	s := d.state
+	if s == nil {
+		return nil
+	}
+	if s.caps != nil || s.probing || s.notTape {
+		return s.caps
+	}
+	s.probing = true
+	defer func() { s.probing = false }()
+	inq, err := d.Inquiry()
+	if err != nil {
+		return nil
+	}
+	if inq.PeripheralType != PeripheralSequentialAccess {
+		s.notTape = true
+		return nil
+	}
+	d.Capabilities()
+	return s.caps
+}
+
+// cmdTimeout returns the timeout for cdb, from the capabilities of the drive if known
+func (d Drive) cmdTimeout(cdb []byte, def uint32) uint32 {
+	if len(cdb) < 2 {
+		return def
+	}
+	return d.caps().Timeout(cdb[0], uint16(cdb[1]&0x1f), def)
+}
@@ -1,5 +1,132 @@
 package tape
 
-func (d Drive) logSense(page byte, recvLen int, sense []byte) {
-	// Dummy function to illustrate the file content.
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// Page control of LOG SENSE
+const (
+	LogPCCurrentThreshold  = 0b00
+	LogPCCurrentCumulative = 0b01
+	LogPCDefaultThreshold  = 0b10
+	LogPCDefaultCumulative = 0b11
+)
+
+const logSenseMaxLen = 0xfffc
+
+type LogParameter struct {
+	Code    uint16
+	Control byte // DU, TSD, ETC, TMC and FORMAT AND LINKING bits
+	Value   []byte
+}
+
+// Uint returns binary counter parameters as integer
+func (p LogParameter) Uint() uint64 {
+	var v uint64
+	for _, b := range p.Value {
+		v = v<<8 | uint64(b)
+	}
+	return v
+}
+
+type LogPage struct {
+	Page    byte
+	Subpage byte
+	Params  []LogParameter
+}
+
+func (lp LogPage) Param(code uint16) (LogParameter, bool) {
+	for _, p := range lp.Params {
+		if p.Code == code {
+			return p, true
+		}
+	}
+	return LogParameter{}, false
+}
+
+// parseLogPage parses one LOG SENSE response, reporting whether the page was truncated
+func parseLogPage(dat []byte) (lp LogPage, truncated bool, err error) {
+	if len(dat) < 4 {
+		return lp, false, errors.New("log sense: short header")
+	}
+	lp.Page = dat[0] & 0x3f
+	lp.Subpage = dat[1]
+	n := int(binary.BigEndian.Uint16(dat[2:]))
+	body := dat[4:]
+	if n > len(body) {
+		truncated = true
+	} else {
+		body = body[:n]
+	}
+	for len(body) >= 4 {
+		l := int(body[3])
+		if len(body) < 4+l {
+			break
+		}
+		lp.Params = append(lp.Params, LogParameter{
+			Code:    binary.BigEndian.Uint16(body),
+			Control: body[2],
+			Value:   body[4 : 4+l],
+		})
+		body = body[4+l:]
+	}
+	return lp, truncated, nil
+}
+
+// LogSense reads all parameters of page/subpage with page control pc,
+// following the parameter pointer when the page exceeds a single transfer
+func (d Drive) LogSense(page, subpage, pc byte) (LogPage, error) {
+	var (
+		all LogPage
+		ptr uint16
+	)
+	for {
+		dat, err := d.scsiRead([]byte{
+			ScsiOpLogSense, 0,
+			pc<<6 | page&0x3f, subpage,
+			0,
+			byte(ptr >> 8), byte(ptr), // ParameterPointer
+			byte(logSenseMaxLen >> 8), byte(logSenseMaxLen & 0xff), // bufLen
+			0}, logSenseMaxLen, 60_000)
+		if err != nil {
+			return all, err
+		}
+		lp, truncated, err := parseLogPage(dat)
+		if err != nil {
+			return all, err
+		}
+		if lp.Page != page&0x3f {
+			return all, fmt.Errorf("log sense: asked page %#x, got %#x", page, lp.Page)
+		}
+		all.Page, all.Subpage = lp.Page, lp.Subpage
+		all.Params = append(all.Params, lp.Params...)
+		if !truncated || len(lp.Params) == 0 {
+			return all, nil
+		}
+		last := lp.Params[len(lp.Params)-1].Code
+		if last == 0xffff {
+			return all, nil
+		}
+		ptr = last + 1
+	}
+}
+
+// SupportedLogPages lists page codes reported by LogPageSupportedPages
+func (d Drive) SupportedLogPages() ([]byte, error) {
+	dat, err := d.scsiRead([]byte{
+		ScsiOpLogSense, 0,
+		LogPCCurrentCumulative<<6 | LogPageSupportedPages, 0,
+		0, 0, 0,
+		0x0, 0xff, // bufLen
+		0}, 0xff, 60_000)
+	if err != nil {
+		return nil, err
+	}
+	if len(dat) < 4 {
+		return nil, errors.New("log sense: short header")
+	}
+	n := min(int(binary.BigEndian.Uint16(dat[2:])), len(dat)-4)
+	return dat[4 : 4+n], nil
 }
@@ -0,0 +1,98 @@
+package tape
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// SelfTest runs the default self-test of the drive by SEND DIAGNOSTIC, it neither moves nor
+// writes the medium. A failed test is returned as the sense reported by the drive.
+func (d Drive) SelfTest() error {
+	return d.scsiCmd([]byte{ScsiOpSendDiagnostic, 0x04, 0, 0, 0, 0}, 30*60_000) // SELFTEST
+}
+
+// Modes of READ BUFFER
+const (
+	ReadBufferData       = 0x02
+	ReadBufferDescriptor = 0x03
+)
+
+// Buffers holding the drive dump
+const (
+	DumpBufferIBMLTO        = 0x01
+	DumpBufferIBMEnterprise = 0x00
+)
+
+const readBufferChunk = 512 << 10
+
+func (d Drive) readBuffer(mode, id byte, offset uint32, n uint32) ([]byte, error) {
+	return d.scsiRead([]byte{
+		ScsiOpReadBuffer, mode & 0x1f, id,
+		byte(offset >> 16), byte(offset >> 8), byte(offset),
+		byte(n >> 16), byte(n >> 8), byte(n),
+		0,
+	}, n, 120_000)
+}
+
+// ReadBufferCapacity returns the size of buffer id
+func (d Drive) ReadBufferCapacity(id byte) (uint32, error) {
+	dat, err := d.readBuffer(ReadBufferDescriptor, id, 0, 4)
+	if err != nil {
+		return 0, err
+	}
+	if len(dat) < 4 {
+		return 0, fmt.Errorf("read buffer: short descriptor %d", len(dat))
+	}
+	return uint32(dat[1])<<16 | uint32(dat[2])<<8 | uint32(dat[3]), nil
+}
+
+// ReadBufferTo copies buffer id to w in chunks, e.g. DumpBufferIBMLTO for the drive dump vendors ask for
+func (d Drive) ReadBufferTo(w io.Writer, id byte) (int64, error) {
+	size, err := d.ReadBufferCapacity(id)
+	if err != nil {
+		return 0, err
+	}
+	if size == 0 {
+		return 0, errors.New("read buffer: buffer is empty")
+	}
+	var written int64
+	for off := uint32(0); off < size; {
+		dat, err := d.readBuffer(ReadBufferData, id, off, min(size-off, readBufferChunk))
+		if err != nil {
+			return written, fmt.Errorf("read buffer at %d of %d: %w", off, size, err)
+		}
+		if len(dat) == 0 {
+			return written, io.ErrUnexpectedEOF
+		}
+		n, err := w.Write(dat)
+		written += int64(n)
+		if err != nil {
+			return written, err
+		}
+		off += uint32(len(dat))
+	}
+	return written, nil
+}
+
+// firmwareTraceLogLen is the allocation length for the firmware trace log, larger than
+// the drives keep
+const firmwareTraceLogLen = 4 << 20
+
+// ReadFirmwareTraceLog copies the firmware trace log of HP drives to w, by their vendor
+// MAINTENANCE IN command. Other drives refuse it with ErrIllegalRequest.
+func (d Drive) ReadFirmwareTraceLog(w io.Writer) (int64, error) {
+	n := uint32(firmwareTraceLogLen)
+	dat, err := d.scsiRead([]byte{
+		byte(ScsiOpReadFirmwareTraceLog >> 16), byte(ScsiOpReadFirmwareTraceLog >> 8 & 0x1f),
+		byte(ScsiOpReadFirmwareTraceLog & 0xff),
+		0, 0, 0,
+		byte(n >> 24), byte(n >> 16), byte(n >> 8), byte(n),
+		0, 0,
+	}, n, 120_000)
+	if err != nil {
+		return 0, err
+	}
+	written, err := w.Write(dat)
+	return int64(written), err
+}
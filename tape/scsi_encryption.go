@@ -0,0 +1,367 @@
+package tape
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// Tape data encryption, security protocol 0x20 of SSC-4 8.5
+const SecurityProtocolTapeEncryption = 0x20
+
+// Security protocol pages of tape data encryption
+const (
+	EncryptionPageCapabilities = 0x0010 // IN
+	EncryptionPageStatus       = 0x0020 // IN
+	EncryptionPageNextBlock    = 0x0021 // IN
+	EncryptionPageSet          = 0x0010 // OUT, set data encryption
+)
+
+// Encryption modes
+const (
+	EncryptOff      = 0x0
+	EncryptExternal = 0x1 // keys managed by something other than this host
+	EncryptOn       = 0x2
+)
+
+// Decryption modes
+const (
+	DecryptOff   = 0x0
+	DecryptRaw   = 0x1 // encrypted blocks are read as is
+	DecryptOn    = 0x2
+	DecryptMixed = 0x3 // encrypted blocks are decrypted, plain blocks read as is
+)
+
+// Scope of the data encryption parameters
+const (
+	EncryptionScopePublic = 0x0
+	EncryptionScopeLocal  = 0x1 // this I_T nexus only
+	EncryptionScopeAll    = 0x2 // all I_T nexuses
+)
+
+const AlgorithmAES256GCM = 0x00010014
+
+// Key-associated data descriptor types
+const (
+	KADUnauthenticated = 0x00
+	KADAuthenticated   = 0x01
+	KADNonce           = 0x02
+	KADMetadata        = 0x03
+)
+
+// ErrNotEncrypting is returned by writes of a Drive on which encryption was enabled
+// but the drive does not report it active, e.g. after the key was lost with a reset.
+var ErrNotEncrypting = errors.New("encryption requested but not active")
+
+type KAD struct {
+	Type          byte
+	Authenticated byte
+	Value         []byte
+}
+
+func parseKADs(b []byte) ([]KAD, error) {
+	var kads []KAD
+	for len(b) >= 4 {
+		n := int(binary.BigEndian.Uint16(b[2:]))
+		if len(b) < 4+n {
+			return kads, errors.New("key-associated data: truncated")
+		}
+		kads = append(kads, KAD{Type: b[0], Authenticated: b[1] & 0b111, Value: b[4 : 4+n]})
+		b = b[4+n:]
+	}
+	return kads, nil
+}
+
+// unauthenticatedKAD returns the U-KAD of kads, with trailing padding removed
+func unauthenticatedKAD(kads []KAD) string {
+	for _, k := range kads {
+		if k.Type == KADUnauthenticated {
+			return string(trimKAD(k.Value))
+		}
+	}
+	return ""
+}
+
+func trimKAD(v []byte) []byte {
+	for len(v) > 0 && (v[len(v)-1] == 0 || v[len(v)-1] == ' ') {
+		v = v[:len(v)-1]
+	}
+	return v
+}
+
+func (d Drive) securityProtocolIn(page uint16) ([]byte, error) {
+	recvLen := uint32(8192)
+	return d.scsiRead([]byte{
+		ScsiOpSecurityProtocolIn, SecurityProtocolTapeEncryption,
+		byte(page >> 8), byte(page),
+		0, 0, // INC_512
+		byte(recvLen >> 24), byte(recvLen >> 16), byte(recvLen >> 8), byte(recvLen),
+		0, 0,
+	}, recvLen, 60_000)
+}
+
+// encryptionPage checks the header of page in dat and cuts dat to its length
+func encryptionPage(dat []byte, page uint16, minLen int) ([]byte, error) {
+	if len(dat) < 4 || binary.BigEndian.Uint16(dat) != page {
+		return nil, fmt.Errorf("encryption page %#04x: bad header %x", page, dat[:min(len(dat), 4)])
+	}
+	n := int(binary.BigEndian.Uint16(dat[2:])) + 4
+	if n > len(dat) {
+		n = len(dat)
+	}
+	if n < minLen {
+		return nil, fmt.Errorf("encryption page %#04x: short data %d", page, n)
+	}
+	return dat[:n], nil
+}
+
+type EncryptionAlgorithm struct {
+	Index      byte
+	Code       uint32 // AlgorithmAES256GCM, ...
+	KeyLength  uint16
+	MaxUKAD    uint16
+	MaxAKAD    uint16
+	CanEncrypt bool // by SECURITY PROTOCOL OUT
+	CanDecrypt bool
+}
+
+// EncryptionCapabilities reads the algorithms supported by the drive
+func (d Drive) EncryptionCapabilities() ([]EncryptionAlgorithm, error) {
+	dat, err := d.securityProtocolIn(EncryptionPageCapabilities)
+	if err != nil {
+		return nil, err
+	}
+	dat, err = encryptionPage(dat, EncryptionPageCapabilities, 20)
+	if err != nil {
+		return nil, err
+	}
+	var algs []EncryptionAlgorithm
+	for b := dat[20:]; len(b) >= 4; {
+		n := int(binary.BigEndian.Uint16(b[2:])) + 4
+		if n < 24 || len(b) < n {
+			return algs, fmt.Errorf("encryption capabilities: bad algorithm descriptor %x", b)
+		}
+		algs = append(algs, EncryptionAlgorithm{
+			Index:      b[0],
+			Code:       binary.BigEndian.Uint32(b[20:]),
+			KeyLength:  binary.BigEndian.Uint16(b[10:]),
+			MaxUKAD:    binary.BigEndian.Uint16(b[6:]),
+			MaxAKAD:    binary.BigEndian.Uint16(b[8:]),
+			CanEncrypt: b[4]&0b10 != 0,
+			CanDecrypt: b[4]&0b1000 != 0,
+		})
+		b = b[n:]
+	}
+	return algs, nil
+}
+
+type EncryptionStatus struct {
+	Scope       byte // I_T nexus scope
+	KeyScope    byte
+	Encrypt     byte // EncryptOff, ...
+	Decrypt     byte // DecryptOff, ...
+	Algorithm   byte // index
+	KeyInstance uint32
+	KAD         []KAD
+}
+
+// Active reports whether blocks written now get encrypted
+func (s EncryptionStatus) Active() bool { return s.Encrypt == EncryptOn }
+
+// UKAD returns the unauthenticated key-associated data of the key in use, the key name
+// for keys set by EnableEncryption
+func (s EncryptionStatus) UKAD() string { return unauthenticatedKAD(s.KAD) }
+
+func (s EncryptionStatus) String() string {
+	return fmt.Sprintf("encrypt=%d decrypt=%d algorithm=%d key=%q instance=%d",
+		s.Encrypt, s.Decrypt, s.Algorithm, s.UKAD(), s.KeyInstance)
+}
+
+func (d Drive) EncryptionStatus() (EncryptionStatus, error) {
+	dat, err := d.securityProtocolIn(EncryptionPageStatus)
+	if err != nil {
+		return EncryptionStatus{}, err
+	}
+	dat, err = encryptionPage(dat, EncryptionPageStatus, 24)
+	if err != nil {
+		return EncryptionStatus{}, err
+	}
+	s := EncryptionStatus{
+		Scope:       dat[4] >> 5,
+		KeyScope:    dat[4] & 0b111,
+		Encrypt:     dat[5],
+		Decrypt:     dat[6],
+		Algorithm:   dat[7],
+		KeyInstance: binary.BigEndian.Uint32(dat[8:]),
+	}
+	s.KAD, err = parseKADs(dat[24:])
+	return s, err
+}
+
+// Encryption status of the next block
+const (
+	NextBlockIncapable            = 0x0
+	NextBlockUnknown              = 0x1 // not able to determine at this position
+	NextBlockNotData              = 0x2 // a filemark or end of data
+	NextBlockPlain                = 0x3
+	NextBlockEncryptedUnsupported = 0x4 // by an algorithm the drive does not support
+	NextBlockEncrypted            = 0x5
+	NextBlockEncryptedNoKey       = 0x6 // the key set does not decrypt it
+)
+
+type NextBlockEncryption struct {
+	Object      uint64 // logical object number the status is for
+	Compression byte
+	Status      byte // NextBlockPlain, ...
+	Algorithm   byte
+	KAD         []KAD
+}
+
+// UKAD returns the key name written with the block by EnableEncryption
+func (s NextBlockEncryption) UKAD() string { return unauthenticatedKAD(s.KAD) }
+
+// NextBlockEncryption reads whether the block at the current position is encrypted and with which key
+func (d Drive) NextBlockEncryption() (NextBlockEncryption, error) {
+	dat, err := d.securityProtocolIn(EncryptionPageNextBlock)
+	if err != nil {
+		return NextBlockEncryption{}, err
+	}
+	dat, err = encryptionPage(dat, EncryptionPageNextBlock, 16)
+	if err != nil {
+		return NextBlockEncryption{}, err
+	}
+	s := NextBlockEncryption{
+		Object:      binary.BigEndian.Uint64(dat[4:]),
+		Compression: dat[12] >> 4,
+		Status:      dat[12] & 0x0f,
+		Algorithm:   dat[13],
+	}
+	s.KAD, err = parseKADs(dat[16:])
+	return s, err
+}
+
+type EncryptionParams struct {
+	Scope     byte // 0 selects EncryptionScopeAll
+	Lock      bool // keep the parameters until reset even against other hosts
+	CKOD      bool // clear the key on demount
+	Encrypt   byte
+	Decrypt   byte
+	Algorithm byte // index from EncryptionCapabilities
+	Key       []byte
+	UKAD      []byte // written with every encrypted block, readable without the key
+}
+
+// SetEncryption sets the data encryption parameters by SECURITY PROTOCOL OUT, they are set
+// again after a reset. Writes fail with ErrNotEncrypting from then on until the drive reports
+// encryption active, unless p.Encrypt is EncryptOff.
+func (d Drive) SetEncryption(p EncryptionParams) error {
+	scope := p.Scope
+	if scope == EncryptionScopePublic {
+		scope = EncryptionScopeAll
+	}
+	buf := make([]byte, 20, 20+len(p.Key)+4+len(p.UKAD))
+	binary.BigEndian.PutUint16(buf, EncryptionPageSet)
+	buf[4] = scope << 5
+	buf[4] = setBit(buf[4], 0x01, p.Lock)
+	buf[5] = setBit(0, 0x04, p.CKOD)
+	buf[6] = p.Encrypt
+	buf[7] = p.Decrypt
+	buf[8] = p.Algorithm
+	buf[9] = 0 // plain text key
+	buf[10] = 0
+	binary.BigEndian.PutUint16(buf[18:], uint16(len(p.Key)))
+	buf = append(buf, p.Key...)
+	if len(p.UKAD) > 0 {
+		buf = append(buf, KADUnauthenticated, 0)
+		buf = binary.BigEndian.AppendUint16(buf, uint16(len(p.UKAD)))
+		buf = append(buf, p.UKAD...)
+	}
+	binary.BigEndian.PutUint16(buf[2:], uint16(len(buf)-4))
+	n := len(buf)
+	err := d.scsiWrite([]byte{
+		ScsiOpSecurityProtocolOut, SecurityProtocolTapeEncryption,
+		byte(EncryptionPageSet >> 8), byte(EncryptionPageSet & 0xff),
+		0, 0, // INC_512
+		byte(n >> 24), byte(n >> 16), byte(n >> 8), byte(n),
+		0, 0,
+	}, buf, 60_000)
+	clear(buf)
+	if err != nil {
+		return err
+	}
+	d.state.encryptVerified = false
+	d.state.encryption = nil
+	if p.Encrypt != EncryptOff || p.Decrypt != DecryptOff {
+		p.Key = append([]byte(nil), p.Key...)
+		d.state.encryption = &p
+	}
+	return nil
+}
+
+// EnableEncryption encrypts written blocks with AES-256-GCM by key name of ks,
+// and decrypts blocks read with it while passing plain ones
+func (d Drive) EnableEncryption(ks *Keystore, name string) error {
+	return d.setKey(ks, name, EncryptOn)
+}
+
+// EnableDecryption decrypts blocks read with key name of ks, writes stay plain
+func (d Drive) EnableDecryption(ks *Keystore, name string) error {
+	return d.setKey(ks, name, EncryptOff)
+}
+
+func (d Drive) setKey(ks *Keystore, name string, encrypt byte) error {
+	key, err := ks.Key(name)
+	if err != nil {
+		return err
+	}
+	algs, err := d.EncryptionCapabilities()
+	if err != nil {
+		return err
+	}
+	for _, a := range algs {
+		if a.Code != AlgorithmAES256GCM || !a.CanDecrypt || (encrypt == EncryptOn && !a.CanEncrypt) {
+			continue
+		}
+		if len(key) != int(a.KeyLength) {
+			return fmt.Errorf("key %q is %d bytes, AES-256-GCM takes %d", name, len(key), a.KeyLength)
+		}
+		if len(name) > int(a.MaxUKAD) {
+			return fmt.Errorf("key name %q longer than %d bytes the drive records", name, a.MaxUKAD)
+		}
+		err = d.SetEncryption(EncryptionParams{
+			Encrypt:   encrypt,
+			Decrypt:   DecryptMixed,
+			Algorithm: a.Index,
+			Key:       key,
+			UKAD:      []byte(name),
+		})
+		if err != nil {
+			return err
+		}
+		return d.requireEncryption()
+	}
+	return errors.New("drive does not support AES-256-GCM encryption")
+}
+
+// DisableEncryption clears the key and turns encryption and decryption off
+func (d Drive) DisableEncryption() error {
+	return d.SetEncryption(EncryptionParams{})
+}
+
+// requireEncryption fails unless encryption set by SetEncryption is active, asking the drive
+// once after it was set or possibly lost
+func (d Drive) requireEncryption() error {
+	p := d.state.encryption
+	if p == nil || p.Encrypt == EncryptOff || d.state.encryptVerified {
+		return nil
+	}
+	s, err := d.EncryptionStatus()
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrNotEncrypting, err)
+	}
+	if s.Encrypt != p.Encrypt || s.Algorithm != p.Algorithm {
+		return fmt.Errorf("%w: drive reports %v", ErrNotEncrypting, s)
+	}
+	d.state.encryptVerified = true
+	return nil
+}
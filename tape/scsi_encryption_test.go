@@ -0,0 +1,198 @@
+package tape
+
+import (
+	"bytes"
+	"encoding/binary"
+	"reflect"
+	"testing"
+)
+
+// encryptionDrive replays cmds on a Drive without recovery
+func encryptionDrive(cmds ...TraceRecord) Drive {
+	d := Drive{direct: true, state: &driveState{notTape: true, replay: &replay{trace: &Trace{Commands: cmds}}}}
+	d.SetRecoveryPolicy(NoRecovery)
+	return d
+}
+
+func spinRecord(page uint16, dat []byte) TraceRecord {
+	return TraceRecord{
+		CDB:      []byte{ScsiOpSecurityProtocolIn, SecurityProtocolTapeEncryption, byte(page >> 8), byte(page), 0, 0, 0, 0, 0x20, 0, 0, 0},
+		Dir:      sgDxferFromDev,
+		DxferLen: 8192,
+		DataIn:   dat,
+	}
+}
+
+// encryptionPageData prefixes body with the header of page
+func encryptionPageData(page uint16, body ...[]byte) []byte {
+	b := binary.BigEndian.AppendUint16(nil, page)
+	b = append(b, 0, 0)
+	for _, p := range body {
+		b = append(b, p...)
+	}
+	binary.BigEndian.PutUint16(b[2:], uint16(len(b)-4))
+	return b
+}
+
+func kadData(typ byte, v string) []byte {
+	b := binary.BigEndian.AppendUint16([]byte{typ, 0}, uint16(len(v)))
+	return append(b, v...)
+}
+
+func algorithmDescriptor(index, flags byte, ukad, akad, keyLen uint16, code uint32) []byte {
+	b := make([]byte, 24)
+	b[0] = index
+	binary.BigEndian.PutUint16(b[2:], 20)
+	b[4] = flags
+	binary.BigEndian.PutUint16(b[6:], ukad)
+	binary.BigEndian.PutUint16(b[8:], akad)
+	binary.BigEndian.PutUint16(b[10:], keyLen)
+	binary.BigEndian.PutUint32(b[20:], code)
+	return b
+}
+
+func TestEncryptionPages(t *testing.T) {
+	capsHeader := make([]byte, 16)
+	capsHeader[0] = 0x09 // EXTDECC, CFG_P
+	status := func(scope, encrypt, decrypt, alg byte, instance uint32) []byte {
+		b := make([]byte, 20)
+		b[0], b[1], b[2], b[3] = scope, encrypt, decrypt, alg
+		binary.BigEndian.PutUint32(b[4:], instance)
+		return b
+	}
+	nextBlock := func(object uint64, cmpStatus, alg byte) []byte {
+		b := binary.BigEndian.AppendUint64(nil, object)
+		return append(b, cmpStatus, alg, 0, 0)
+	}
+	tests := []struct {
+		name string
+		page uint16
+		dat  []byte
+		want any
+		err  bool
+	}{
+		{name: "capabilities LTO", page: EncryptionPageCapabilities,
+			dat: encryptionPageData(EncryptionPageCapabilities, capsHeader,
+				algorithmDescriptor(1, 0x8a, 32, 12, 32, AlgorithmAES256GCM)),
+			want: []EncryptionAlgorithm{{Index: 1, Code: AlgorithmAES256GCM, KeyLength: 32, MaxUKAD: 32, MaxAKAD: 12,
+				CanEncrypt: true, CanDecrypt: true}}},
+		{name: "capabilities decrypt only", page: EncryptionPageCapabilities,
+			dat: encryptionPageData(EncryptionPageCapabilities, capsHeader,
+				algorithmDescriptor(1, 0x88, 32, 12, 32, AlgorithmAES256GCM),
+				algorithmDescriptor(2, 0x00, 0, 0, 16, 0x00010010)),
+			want: []EncryptionAlgorithm{
+				{Index: 1, Code: AlgorithmAES256GCM, KeyLength: 32, MaxUKAD: 32, MaxAKAD: 12, CanDecrypt: true},
+				{Index: 2, Code: 0x00010010, KeyLength: 16},
+			}},
+		{name: "capabilities no algorithms", page: EncryptionPageCapabilities,
+			dat: encryptionPageData(EncryptionPageCapabilities, capsHeader), want: []EncryptionAlgorithm(nil)},
+		{name: "capabilities short descriptor", page: EncryptionPageCapabilities,
+			dat: encryptionPageData(EncryptionPageCapabilities, capsHeader,
+				algorithmDescriptor(1, 0x8a, 32, 12, 32, AlgorithmAES256GCM)[:20]), err: true},
+		{name: "capabilities wrong page", page: EncryptionPageCapabilities,
+			dat: encryptionPageData(EncryptionPageStatus, capsHeader), err: true},
+
+		{name: "status encrypting", page: EncryptionPageStatus,
+			dat: encryptionPageData(EncryptionPageStatus, status(EncryptionScopeAll<<5|0x1, EncryptOn, DecryptMixed, 1, 7),
+				kadData(KADUnauthenticated, "tape1   "), kadData(KADAuthenticated, "meta")),
+			want: EncryptionStatus{Scope: EncryptionScopeAll, KeyScope: 1, Encrypt: EncryptOn, Decrypt: DecryptMixed,
+				Algorithm: 1, KeyInstance: 7, KAD: []KAD{
+					{Type: KADUnauthenticated, Value: []byte("tape1   ")},
+					{Type: KADAuthenticated, Value: []byte("meta")},
+				}}},
+		{name: "status off", page: EncryptionPageStatus,
+			dat:  encryptionPageData(EncryptionPageStatus, status(0, EncryptOff, DecryptOff, 0, 0)),
+			want: EncryptionStatus{}},
+		{name: "status truncated KAD", page: EncryptionPageStatus,
+			dat: encryptionPageData(EncryptionPageStatus, status(0, EncryptOn, DecryptOn, 1, 1),
+				kadData(KADUnauthenticated, "tape1")[:6]), err: true},
+		{name: "status short", page: EncryptionPageStatus,
+			dat: encryptionPageData(EncryptionPageStatus, status(0, EncryptOn, DecryptOn, 1, 1)[:16]), err: true},
+
+		{name: "next block encrypted", page: EncryptionPageNextBlock,
+			dat: encryptionPageData(EncryptionPageNextBlock, nextBlock(1234, 0x20|NextBlockEncrypted, 1),
+				kadData(KADUnauthenticated, "tape1")),
+			want: NextBlockEncryption{Object: 1234, Compression: 2, Status: NextBlockEncrypted, Algorithm: 1,
+				KAD: []KAD{{Type: KADUnauthenticated, Value: []byte("tape1")}}}},
+		{name: "next block plain", page: EncryptionPageNextBlock,
+			dat:  encryptionPageData(EncryptionPageNextBlock, nextBlock(1<<33, NextBlockPlain, 0)),
+			want: NextBlockEncryption{Object: 1 << 33, Status: NextBlockPlain}},
+		{name: "next block short", page: EncryptionPageNextBlock,
+			dat: encryptionPageData(EncryptionPageNextBlock, nextBlock(0, 0, 0)[:8]), err: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := encryptionDrive(spinRecord(tt.page, tt.dat))
+			var got any
+			var err error
+			switch tt.page {
+			case EncryptionPageCapabilities:
+				got, err = d.EncryptionCapabilities()
+			case EncryptionPageStatus:
+				got, err = d.EncryptionStatus()
+			case EncryptionPageNextBlock:
+				got, err = d.NextBlockEncryption()
+			}
+			if tt.err {
+				if err == nil {
+					t.Fatalf("got %+v, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	s := EncryptionStatus{KAD: []KAD{{Type: KADAuthenticated, Value: []byte("a")}, {Type: KADUnauthenticated, Value: []byte("tape1 \x00")}}}
+	if s.UKAD() != "tape1" {
+		t.Errorf("UKAD() = %q", s.UKAD())
+	}
+}
+
+func TestSetEncryptionData(t *testing.T) {
+	key := bytes.Repeat([]byte{0xa5}, 32)
+	// the page as the drive gets it, the key bytes are compared redacted
+	page := func(scopeLock, ckod, encrypt, decrypt, alg byte, key []byte, kad []byte) []byte {
+		b := make([]byte, 14)
+		b[0], b[1], b[2], b[3], b[4] = scopeLock, ckod, encrypt, decrypt, alg
+		b = binary.BigEndian.AppendUint16(b, uint16(len(key)))
+		b = append(b, key...)
+		return encryptionPageData(EncryptionPageSet, b, kad)
+	}
+	tests := []struct {
+		name string
+		p    EncryptionParams
+		out  []byte
+	}{
+		{"encrypt with key name", EncryptionParams{Encrypt: EncryptOn, Decrypt: DecryptMixed, Algorithm: 1, Key: key, UKAD: []byte("tape1")},
+			page(EncryptionScopeAll<<5, 0, EncryptOn, DecryptMixed, 1, key, kadData(KADUnauthenticated, "tape1"))},
+		{"local locked CKOD", EncryptionParams{Scope: EncryptionScopeLocal, Lock: true, CKOD: true, Encrypt: EncryptOff, Decrypt: DecryptOn, Algorithm: 2, Key: key},
+			page(EncryptionScopeLocal<<5|0x01, 0x04, EncryptOff, DecryptOn, 2, key, nil)},
+		{"disable", EncryptionParams{},
+			page(EncryptionScopeAll<<5, 0, EncryptOff, DecryptOff, 0, nil, nil)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := len(tt.out)
+			d := encryptionDrive(TraceRecord{
+				CDB: []byte{ScsiOpSecurityProtocolOut, SecurityProtocolTapeEncryption, byte(EncryptionPageSet >> 8), byte(EncryptionPageSet),
+					0, 0, byte(n >> 24), byte(n >> 16), byte(n >> 8), byte(n), 0, 0},
+				Dir:      sgDxferToDev,
+				DxferLen: uint32(n),
+				DataOut:  redactKey([]byte{ScsiOpSecurityProtocolOut, SecurityProtocolTapeEncryption, byte(EncryptionPageSet >> 8), byte(EncryptionPageSet)}, tt.out),
+			})
+			// replay fails the command if CDB or data-out differ
+			if err := d.SetEncryption(tt.p); err != nil {
+				t.Fatal(err)
+			}
+			if got := d.state.encryption; (got != nil) != (tt.p.Encrypt != EncryptOff || tt.p.Decrypt != DecryptOff) {
+				t.Fatalf("encryption state %+v", got)
+			}
+		})
+	}
+}
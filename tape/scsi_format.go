@@ -0,0 +1,70 @@
+package tape
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// FORMAT field of FORMAT MEDIUM
+const (
+	FormatDefault          = 0x00
+	FormatPartition        = 0x01
+	FormatDefaultPartition = 0x02 // format then partition per MediumPartitionPage
+)
+
+// PartitionLayout describes partitions to create, sizes are in 10^Units bytes
+// and 0xFFFF assigns the remaining capacity.
+type PartitionLayout struct {
+	Units byte
+	Sizes []uint16
+}
+
+// LTFSLayout is an index partition of indexGB and a data partition of the remaining capacity
+func LTFSLayout(indexGB uint16) PartitionLayout {
+	return PartitionLayout{Units: 9, Sizes: []uint16{indexGB, 0xffff}}
+}
+
+// FormatMedium issues FORMAT MEDIUM with Immed set and waits for it to finish,
+// progress receives the fraction done reported by the drive.
+func (d Drive) FormatMedium(format byte, progress func(float64)) error {
+	return d.FormatMediumContext(context.Background(), format, progress)
+}
+
+// Partition sets up MediumPartitionPage to layout and formats the medium, erasing all data
+func (d Drive) Partition(layout PartitionLayout, progress func(float64)) error {
+	return d.PartitionContext(context.Background(), layout, progress)
+}
+
+func (d Drive) PartitionContext(ctx context.Context, layout PartitionLayout, progress func(float64)) error {
+	if len(layout.Sizes) == 0 {
+		return errors.New("partition: empty layout")
+	}
+	var mp MediumPartitionPage
+	_, err := d.ReadModePage(&mp, ModePCCurrent)
+	if err != nil {
+		return err
+	}
+	if len(layout.Sizes)-1 > int(mp.MaxAdditionalPartitions) {
+		return fmt.Errorf("partition: drive supports %d partitions, asked %d", mp.MaxAdditionalPartitions+1, len(layout.Sizes))
+	}
+	mp.AdditionalPartitions = byte(len(layout.Sizes) - 1)
+	mp.FDP, mp.SDP, mp.IDP = false, false, true
+	mp.PSUM = PSUMUnits
+	mp.PartitionUnits = layout.Units
+	mp.POFM = true
+	mp.Sizes = layout.Sizes
+	err = d.WriteModePage(&mp, false)
+	if err != nil {
+		return err
+	}
+	err = d.RewindContext(ctx, nil)
+	if err != nil {
+		return err
+	}
+	err = d.FormatMediumContext(ctx, FormatDefaultPartition, progress)
+	if err != nil {
+		return err
+	}
+	return d.CheckParts(len(layout.Sizes))
+}
@@ -0,0 +1,168 @@
+package tape
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"time"
+)
+
+// The Context variants send long operations with Immed set and poll until they finish.
+// progress, if not nil, receives the fraction done reported by the drive.
+// On cancellation they return ctx.Err() while the drive carries on with the operation,
+// later commands get NOT READY until it finishes.
+
+const immedTimeout = 60_000
+
+var pollInterval = time.Second
+
+// waitReady polls TEST UNIT READY until a long operation started with Immed finishes
+func (d Drive) waitReady(ctx context.Context, progress func(float64)) error {
+	t := time.NewTicker(pollInterval)
+	defer t.Stop()
+	for {
+		err := d.TestUnitReady()
+		if err == nil {
+			return nil
+		}
+		var s *SenseError
+		if !errors.As(err, &s) || !s.InProgress() {
+			return err
+		}
+		if progress != nil {
+			p, ok := s.Progress()
+			if !ok {
+				// some drives only report progress with REQUEST SENSE
+				if rs, err := d.RequestSense(); err == nil && rs != nil {
+					p, ok = rs.Progress()
+				}
+			}
+			if ok {
+				progress(p)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
+func (d Drive) immed(ctx context.Context, cdb []byte, progress func(float64)) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	err := d.scsiCmdContext(ctx, cdb, immedTimeout)
+	if err != nil {
+		return err
+	}
+	return d.waitReady(ctx, progress)
+}
+
+// LocateContext locates block of part like LocatePartBlock
+func (d Drive) LocateContext(ctx context.Context, part byte, block uint64, progress func(float64)) error {
+	if d.caps().Supports(ScsiOpLocate16, 0) || block > math.MaxUint32 {
+		return d.immed(ctx, []byte{
+			ScsiOpLocate16, Locate16FlagWithPart | 0x01, 0, part,
+			byte(block >> 56), byte(block >> 48), byte(block >> 40), byte(block >> 32),
+			byte(block >> 24), byte(block >> 16), byte(block >> 8), byte(block),
+			0, 0, 0, 0,
+		}, progress)
+	}
+	return d.immed(ctx, []byte{
+		ScsiOpLocate10, Locate10FlagWithPart | 0x01, 0,
+		byte(block >> 24), byte(block >> 16), byte(block >> 8), byte(block),
+		0, part, 0,
+	}, progress)
+}
+
+func (d Drive) RewindContext(ctx context.Context, progress func(float64)) error {
+	return d.immed(ctx, []byte{ScsiOpRewind, 0x01, 0, 0, 0, 0}, progress)
+}
+
+// EraseContext erases from the current position, long erases to the end of partition
+func (d Drive) EraseContext(ctx context.Context, long bool, progress func(float64)) error {
+	var flags byte = 0x02 // IMMED
+	if long {
+		flags |= 0x01
+	}
+	return d.immed(ctx, []byte{ScsiOpErase, flags, 0, 0, 0, 0}, progress)
+}
+
+// FormatMediumContext issues FORMAT MEDIUM, see FormatMedium
+func (d Drive) FormatMediumContext(ctx context.Context, format byte, progress func(float64)) error {
+	return d.immed(ctx, []byte{ScsiOpFormatMedium, 0x01, format & 0x0f, 0, 0, 0}, progress)
+}
+
+// LOAD UNLOAD flags
+const (
+	LoadFlagLoad  = 0x01
+	LoadFlagReten = 0x02
+	LoadFlagEOT   = 0x04
+	LoadFlagHold  = 0x08 // keep the medium in the drive, positioned at BOP
+)
+
+// LoadContext sends LOAD UNLOAD with flags, LoadFlagLoad loads and its absence unloads
+func (d Drive) LoadContext(ctx context.Context, flags byte, progress func(float64)) error {
+	return d.immed(ctx, []byte{ScsiOpLoadUnload, 0x01, 0, 0, flags & 0x0f, 0}, progress)
+}
+
+func (d Drive) UnloadContext(ctx context.Context, progress func(float64)) error {
+	return d.LoadContext(ctx, 0, progress)
+}
+
+type ctxReader struct {
+	ctx context.Context
+	r   *StreamReader
+}
+
+func (c ctxReader) Read(p []byte) (int, error) {
+	if err := c.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return c.r.Read(p)
+}
+
+// maxBlockLength bounds the blocks read when the drive did not report its limit, as of LTO
+const maxBlockLength = 8 << 20
+
+// WriteToContext is WriteTo stopping between commands when ctx is done
+func (d Drive) WriteToContext(ctx context.Context, wr io.Writer) error {
+	if !isSG(d.fd) || d.state.lbp != LBPNone {
+		// no streaming through st, nor of blocks carrying their CRC
+		return d.copyBlocks(ctx, wr)
+	}
+	r, err := d.NewStreamReader(StreamOptions{})
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	_, err = io.Copy(wr, ctxReader{ctx, r})
+	return err
+}
+
+// copyBlocks copies blocks into wr by ReadBlock one at a time until the filemark
+func (d Drive) copyBlocks(ctx context.Context, wr io.Writer) error {
+	size := uint32(maxBlockLength)
+	if c := d.caps(); c != nil && c.MaxBlockLength > 0 {
+		size = c.MaxBlockLength
+	}
+	buf := make([]byte, size)
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		n, err := d.ReadBlock(buf)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if _, err = wr.Write(buf[:n]); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,207 @@
+package tape
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Peripheral device types of INQUIRY
+const (
+	PeripheralSequentialAccess = 0x01
+	PeripheralMediumChanger    = 0x08
+)
+
+// VPD pages of INQUIRY
+const (
+	VPDSupportedPages        = 0x00
+	VPDUnitSerialNumber      = 0x80
+	VPDDeviceIdentification  = 0x83
+	VPDSequentialAccess      = 0xB0
+	VPDFirmwareDesignationLo = 0xC0 // vendor specific, IBM and HPE report firmware build here
+	VPDFirmwareDesignationHi = 0xC7
+)
+
+type InquiryData struct {
+	PeripheralQualifier byte
+	PeripheralType      byte
+	Removable           bool
+	Version             byte
+	Vendor              string
+	Product             string
+	Revision            string
+	VendorSpecific      string // bytes 36-55, drive serial on some models
+	Raw                 []byte
+}
+
+func (i InquiryData) String() string {
+	return fmt.Sprintf("%s %s %s (type %#02x)", i.Vendor, i.Product, i.Revision, i.PeripheralType)
+}
+
+func asciiField(b []byte) string {
+	return strings.TrimSpace(strings.TrimRight(string(b), "\x00"))
+}
+
+func parseInquiry(dat []byte) (InquiryData, error) {
+	if len(dat) < 36 {
+		return InquiryData{}, fmt.Errorf("inquiry: short data %d", len(dat))
+	}
+	inq := InquiryData{
+		PeripheralQualifier: dat[0] >> 5,
+		PeripheralType:      dat[0] & 0x1f,
+		Removable:           dat[1]&0x80 != 0,
+		Version:             dat[2],
+		Vendor:              asciiField(dat[8:16]),
+		Product:             asciiField(dat[16:32]),
+		Revision:            asciiField(dat[32:36]),
+		Raw:                 dat,
+	}
+	if len(dat) >= 56 {
+		inq.VendorSpecific = asciiField(dat[36:56])
+	}
+	return inq, nil
+}
+
+// Inquiry reads standard INQUIRY data
+func (d Drive) Inquiry() (InquiryData, error) {
+	dat, err := d.scsiRead([]byte{ScsiOpInquiry, 0, 0, 0, 0xff, 0}, 0xff, 60_000)
+	if err != nil {
+		return InquiryData{}, err
+	}
+	return parseInquiry(dat)
+}
+
+// InquiryVPD reads vital product data page and returns it without the 4 byte header
+func (d Drive) InquiryVPD(page byte) ([]byte, error) {
+	dat, err := d.scsiRead([]byte{ScsiOpInquiry, 0x01, page, 0x01, 0x00, 0}, 0x100, 60_000)
+	if err != nil {
+		return nil, err
+	}
+	if len(dat) < 4 || dat[1] != page {
+		return nil, fmt.Errorf("inquiry: VPD page %#02x not returned", page)
+	}
+	n := min(int(binary.BigEndian.Uint16(dat[2:])), len(dat)-4)
+	return dat[4 : 4+n], nil
+}
+
+func (d Drive) SupportedVPDPages() ([]byte, error) {
+	return d.InquiryVPD(VPDSupportedPages)
+}
+
+// SerialNumber reads VPDUnitSerialNumber
+func (d Drive) SerialNumber() (string, error) {
+	dat, err := d.InquiryVPD(VPDUnitSerialNumber)
+	if err != nil {
+		return "", err
+	}
+	return asciiField(dat), nil
+}
+
+// Designator is a designation descriptor of VPDDeviceIdentification
+type Designator struct {
+	CodeSet     byte // 1 binary, 2 ASCII, 3 UTF-8
+	Association byte // 0 logical unit, 1 target port, 2 target device
+	Type        byte // 0 vendor, 1 T10 vendor ID, 2 EUI-64, 3 NAA, 8 SCSI name
+	Identifier  []byte
+}
+
+const (
+	DesignatorVendor   = 0x0
+	DesignatorT10      = 0x1
+	DesignatorEUI64    = 0x2
+	DesignatorNAA      = 0x3
+	DesignatorSCSIName = 0x8
+)
+
+func (d Designator) String() string {
+	var v string
+	if d.CodeSet == 1 {
+		v = hex.EncodeToString(d.Identifier)
+	} else {
+		v = asciiField(d.Identifier)
+	}
+	switch d.Type {
+	case DesignatorNAA:
+		return "naa." + v
+	case DesignatorEUI64:
+		return "eui." + v
+	case DesignatorT10:
+		return "t10." + v
+	}
+	return v
+}
+
+func parseDesignators(dat []byte) []Designator {
+	var ds []Designator
+	for len(dat) >= 4 {
+		n := int(dat[3])
+		if len(dat) < 4+n {
+			break
+		}
+		ds = append(ds, Designator{
+			CodeSet:     dat[0] & 0x0f,
+			Association: dat[1] >> 4 & 0b11,
+			Type:        dat[1] & 0x0f,
+			Identifier:  dat[4 : 4+n],
+		})
+		dat = dat[4+n:]
+	}
+	return ds
+}
+
+// DeviceIdentification reads VPDDeviceIdentification designators
+func (d Drive) DeviceIdentification() ([]Designator, error) {
+	dat, err := d.InquiryVPD(VPDDeviceIdentification)
+	if err != nil {
+		return nil, err
+	}
+	return parseDesignators(dat), nil
+}
+
+// WWN returns the NAA designator of the logical unit
+func (d Drive) WWN() (string, error) {
+	ds, err := d.DeviceIdentification()
+	if err != nil {
+		return "", err
+	}
+	for _, ds := range ds {
+		if ds.Type == DesignatorNAA && ds.Association == 0 {
+			return ds.String(), nil
+		}
+	}
+	return "", errors.New("inquiry: no NAA designator")
+}
+
+// FirmwareDesignation is a vendor specific VPD page in VPDFirmwareDesignationLo-Hi
+type FirmwareDesignation struct {
+	Page byte
+	Text string // printable content with padding collapsed
+	Raw  []byte
+}
+
+func printable(b []byte) string {
+	fields := strings.FieldsFunc(string(b), func(r rune) bool { return r < 0x21 || r > 0x7e })
+	return strings.Join(fields, " ")
+}
+
+// FirmwareDesignations reads the supported vendor firmware VPD pages
+func (d Drive) FirmwareDesignations() ([]FirmwareDesignation, error) {
+	pages, err := d.SupportedVPDPages()
+	if err != nil {
+		return nil, err
+	}
+	var fds []FirmwareDesignation
+	for _, page := range pages {
+		if page < VPDFirmwareDesignationLo || page > VPDFirmwareDesignationHi {
+			continue
+		}
+		dat, err := d.InquiryVPD(page)
+		if err != nil {
+			return fds, err
+		}
+		fds = append(fds, FirmwareDesignation{Page: page, Text: printable(dat), Raw: dat})
+	}
+	return fds, nil
+}
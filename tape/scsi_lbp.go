@@ -0,0 +1,101 @@
+package tape
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+)
+
+// Logical block protection: with it on, every block carries a CRC appended by the host,
+// checked by the drive on write and on the medium, and returned with the block on read.
+// ReadBlock and WriteBlock of Drive add and verify the CRC on both the st and SG_IO paths,
+// callers see the data only.
+
+// ErrCRC is returned by ReadBlock of a Drive with protection on when a block fails its CRC
+var ErrCRC = errors.New("logical block protection: CRC mismatch")
+
+const lbpInfoLen = 4
+
+var crc32cTable = crc32.MakeTable(crc32.Castagnoli)
+
+// Protection reads the logical block protection set on the drive
+func (d Drive) Protection() (ControlDataProtectionPage, error) {
+	var p ControlDataProtectionPage
+	_, err := d.ReadModePage(&p, ModePCCurrent)
+	return p, err
+}
+
+// SetProtection turns logical block protection with method on for writes and reads,
+// LBPNone turns it off. Only LBPCRC32C is generated on the host, and only in variable block mode.
+func (d Drive) SetProtection(method byte) error {
+	switch method {
+	case LBPNone, LBPCRC32C:
+	default:
+		return fmt.Errorf("logical block protection: method %#02x not supported", method)
+	}
+	if method != LBPNone {
+		bs, err := d.BlockSize()
+		if err != nil {
+			return err
+		}
+		if bs != 0 {
+			return fmt.Errorf("logical block protection: needs variable block mode, block size is %d", bs)
+		}
+	}
+	p, err := d.Protection()
+	if err != nil {
+		return err
+	}
+	p.Method = method
+	p.InfoLength = 0
+	if method != LBPNone {
+		p.InfoLength = lbpInfoLen
+	}
+	p.LBPW = method != LBPNone
+	p.LBPR = method != LBPNone
+	p.RBDP = false
+	err = d.WriteModePage(&p, false)
+	if err != nil {
+		return err
+	}
+	d.state.lbp = method
+	return nil
+}
+
+// lbpScratch returns the buffer of d holding a block with its CRC
+func (d Drive) lbpScratch(n int) []byte {
+	if cap(d.state.lbpBuf) < n {
+		d.state.lbpBuf = make([]byte, n)
+	}
+	return d.state.lbpBuf[:n]
+}
+
+// protect returns buf followed by its CRC32C, little endian as LTFS writes it
+func (d Drive) protect(buf []byte) ([]byte, error) {
+	if d.state.blockSize != 0 {
+		return nil, errors.New("logical block protection: needs variable block mode")
+	}
+	b := d.lbpScratch(len(buf) + lbpInfoLen)
+	copy(b, buf)
+	binary.LittleEndian.PutUint32(b[len(buf):], crc32.Checksum(buf, crc32cTable))
+	return b, nil
+}
+
+// readProtected reads a block with its CRC by read, and returns the data verified in buf
+func (d Drive) readProtected(buf []byte, read func([]byte) (int, error)) (int, error) {
+	b := d.lbpScratch(len(buf) + lbpInfoLen)
+	n, err := read(b)
+	if err != nil || n == 0 {
+		return 0, err
+	}
+	if n < lbpInfoLen {
+		return 0, fmt.Errorf("%w: %d byte block", ErrCRC, n)
+	}
+	n -= lbpInfoLen
+	want := binary.LittleEndian.Uint32(b[n:])
+	if got := crc32.Checksum(b[:n], crc32cTable); got != want {
+		return 0, fmt.Errorf("%w: block has %08x, data %08x", ErrCRC, want, got)
+	}
+	return copy(buf, b[:n]), nil
+}
@@ -1,21 +1,79 @@
 package tape
 
 import (
+	"context"
+	"fmt"
 	"log"
 	"os"
+	"path/filepath"
+	"strings"
 	"syscall"
+	"time"
 	"unsafe"
 )
 
+// Rewind selects the st node to open
+const (
+	RewindAsGiven = iota // open path as is
+	RewindOnClose        // use /dev/stX, the driver rewinds on close
+	NoRewind             // use /dev/nstX
+)
+
+type OpenOptions struct {
+	Write     bool // open read-write, required for WriteBlock and WriteFilemarks
+	Rewind    int
+	Exclusive bool // O_EXCL, refuses other opens of sg nodes while held
+	Direct    bool // move data by SG_IO READ/WRITE instead of the st driver, path may be an sg node
+	Trace     *Tracer
+}
+
+// Open opens path read-only as given
 func Open(path string) (*Drive, error) {
-	f, err := os.OpenFile(path, syscall.O_RDONLY|syscall.O_CLOEXEC, 0)
+	return OpenWith(path, OpenOptions{})
+}
+
+func OpenWith(path string, opts OpenOptions) (*Drive, error) {
+	path = rewindPath(path, opts.Rewind)
+	flag := syscall.O_RDONLY | syscall.O_CLOEXEC
+	if opts.Write {
+		flag = syscall.O_RDWR | syscall.O_CLOEXEC
+	}
+	if opts.Exclusive {
+		flag |= syscall.O_EXCL
+	}
+	f, err := os.OpenFile(path, flag, 0)
 	if err != nil {
 		return nil, err
 	}
-	return &Drive{
-		File: f,
-		fd:   f.Fd(),
-	}, nil
+	d := &Drive{
+		File:   f,
+		fd:     f.Fd(),
+		direct: opts.Direct,
+		state:  &driveState{tracer: opts.Trace},
+	}
+	if opts.Trace != nil {
+		opts.Trace.open(opts.Direct)
+	}
+	if opts.Direct {
+		// the st driver tracks block size itself, SG_IO needs it for READ/WRITE
+		_, err = d.BlockSize()
+		if err != nil {
+			f.Close()
+			return nil, err
+		}
+	}
+	return d, nil
+}
+
+func rewindPath(path string, rewind int) string {
+	dir, name := filepath.Split(path)
+	switch {
+	case rewind == RewindOnClose && strings.HasPrefix(name, "nst"):
+		return dir + name[1:]
+	case rewind == NoRewind && strings.HasPrefix(name, "st"):
+		return dir + "n" + name
+	}
+	return path
 }
 
 //typedef struct sg_io_hdr {
@@ -52,13 +110,13 @@ type sgioHdr struct {
 	mxSbLen        uint8
 	iovecCount     uint16
 	dxferLen       uint32
-	dxferp         uintptr
-	cmdp           uintptr
-	sbp            uintptr
+	dxferp         unsafe.Pointer
+	cmdp           unsafe.Pointer
+	sbp            unsafe.Pointer
 	timeout        uint32
 	flags          uint32
 	packID         int32
-	usrPtr         uintptr
+	usrPtr         unsafe.Pointer
 	status         uint8
 	maskedStatus   uint8
 	msgStatus      uint8
@@ -67,18 +125,24 @@ type sgioHdr struct {
 	driverStatus   uint16
 	resid          int32
 
-	duration uint
-	info     uint
+	duration uint32
+	info     uint32
 }
 
+const (
+	sgDxferNone    = -1
+	sgDxferToDev   = -2
+	sgDxferFromDev = -3
+)
+
 func newScsiCmd(cmd []byte, timeout uint32) sgioHdr {
-	sbp := make(senseError, senseBufferSize)
+	sbp := make([]byte, senseBufferSize)
 	return sgioHdr{
 		interfaceID: 'S',
 
 		cmdLen:  uint8(len(cmd)),
-		cmdp:    uintptr(unsafe.Pointer(&cmd[0])), // unsafe.SliceData(cmd)?
-		sbp:     uintptr(unsafe.Pointer(&sbp[0])),
+		cmdp:    unsafe.Pointer(&cmd[0]), // unsafe.SliceData(cmd)?
+		sbp:     unsafe.Pointer(&sbp[0]),
 		mxSbLen: senseBufferSize,
 
 		timeout: timeout,
@@ -86,43 +150,115 @@ func newScsiCmd(cmd []byte, timeout uint32) sgioHdr {
 }
 
 func (d Drive) scsiCmd(cmd []byte, timeout uint32) error {
-	hdr := newScsiCmd(cmd, timeout)
-	hdr.dxferDirection = -1 // SG_DXFER_NONE
-	return scsi(d.fd, &hdr)
+	return d.scsiCmdContext(context.Background(), cmd, timeout)
+}
+
+// scsiCmdContext is scsiCmd giving up waiting for the drive becoming ready when ctx is done
+func (d Drive) scsiCmdContext(ctx context.Context, cmd []byte, timeout uint32) error {
+	hdr := newScsiCmd(cmd, d.cmdTimeout(cmd, timeout))
+	hdr.dxferDirection = sgDxferNone
+	return d.execContext(ctx, &hdr)
 }
 
 func (d Drive) scsiRead(cmd []byte, recvLen uint32, timeout uint32) ([]byte, error) {
 	buf := make([]byte, recvLen)
-	hdr := newScsiCmd(cmd, timeout)
-	hdr.dxferDirection = -3 // SG_DXFER_FROM_DEV
-	hdr.dxferLen = recvLen
-	hdr.dxferp = uintptr(unsafe.Pointer(&buf[0]))
-	err := scsi(d.fd, &hdr)
-	//log.Println(hdr.resid)
-	return buf[:hdr.resid], err
+	n, err := d.scsiReadInto(cmd, buf, timeout)
+	return buf[:n], err
+}
+
+// scsiReadInto reads into buf and returns the bytes transferred
+func (d Drive) scsiReadInto(cmd, buf []byte, timeout uint32) (int, error) {
+	hdr := newScsiCmd(cmd, d.cmdTimeout(cmd, timeout))
+	hdr.dxferDirection = sgDxferFromDev
+	hdr.dxferLen = uint32(len(buf))
+	hdr.dxferp = unsafe.Pointer(&buf[0])
+	err := d.exec(&hdr)
+	n := len(buf) - int(hdr.resid)
+	if n < 0 || n > len(buf) {
+		n = 0
+	}
+	return n, err
 }
 
 func (d Drive) scsiWrite(cmd, buf []byte, timeout uint32) error {
-	hdr := newScsiCmd(cmd, timeout)
-	hdr.dxferDirection = -2 // SG_DXFER_TO_DEV
+	hdr := newScsiCmd(cmd, d.cmdTimeout(cmd, timeout))
+	hdr.dxferDirection = sgDxferToDev
 	hdr.dxferLen = uint32(len(buf))
-	hdr.dxferp = uintptr(unsafe.Pointer(&buf[0]))
-	return scsi(d.fd, &hdr)
+	hdr.dxferp = unsafe.Pointer(&buf[0])
+	return d.exec(&hdr)
 }
 
-func scsi(fd uintptr, hdr *sgioHdr) error {
-	err := sgIO(fd, hdr)
-	if hdr.sbLenWr != 0 && *(*byte)(unsafe.Pointer(hdr.sbp)) != 0 {
-		log.Printf("scsi: cmd=%x status=%x host=%d driver=%d resid=%d sb=%x\n",
-			unsafe.Slice((*byte)(unsafe.Pointer(hdr.cmdp)), hdr.cmdLen),
-			hdr.status, hdr.hostStatus, hdr.driverStatus, hdr.resid,
-			unsafe.Slice((*byte)(unsafe.Pointer(hdr.sbp)), hdr.sbLenWr))
+// exec issues hdr and retries it as the recovery policy of d allows
+func (d Drive) exec(hdr *sgioHdr) error {
+	return d.execContext(context.Background(), hdr)
+}
+
+func (d Drive) execContext(ctx context.Context, hdr *sgioHdr) error {
+	rc := recovery{ctx: ctx, policy: d.recoveryPolicy()}
+	cdb := unsafe.Slice((*byte)(hdr.cmdp), hdr.cmdLen)
+	if d.state != nil && movesMedium(cdb[0]) {
+		d.state.pos = nil
+		if cdb[0] == ScsiOpLoadUnload || cdb[0] == ScsiOpFormatMedium {
+			d.state.partBlock = nil
+		}
+	}
+	for {
+		err := d.sgio(hdr)
+		if err == nil || !d.retry(&rc, cdb, err) {
+			if rc.cancelled {
+				return ctx.Err()
+			}
+			if err == ErrReservationConflict && cdb[0] != ScsiOpPersistentReserveIn {
+				err = d.reservationConflict()
+			}
+			return err
+		}
+		hdr.sbLenWr, hdr.resid = 0, 0
+		*(*byte)(hdr.sbp) = 0
+	}
+}
 
-		return senseError(unsafe.Slice((*byte)(unsafe.Pointer(hdr.sbp)), hdr.sbLenWr))
+// sgio issues hdr to the device, or the replayed trace, and records it to the tracer
+func (d Drive) sgio(hdr *sgioHdr) error {
+	start := time.Now()
+	var err error
+	if d.state != nil && d.state.replay != nil {
+		err = d.state.replay.serve(hdr)
+	} else {
+		err = sgIO(d.fd, hdr)
+	}
+	if d.state != nil && d.state.tracer != nil {
+		d.state.tracer.record(hdr, err, time.Since(start))
+	}
+	if serr := hdrSense(hdr); serr != nil {
+		return serr
 	}
 	return err
 }
 
+// hdrSense returns the sense data of a completed command as *SenseError, nil if there is none.
+// RESERVATION CONFLICT comes without sense and is returned as ErrReservationConflict.
+func hdrSense(hdr *sgioHdr) error {
+	if hdr.sbLenWr == 0 || *(*byte)(hdr.sbp) == 0 {
+		if hdr.status == scsiStatusReservationConflict {
+			return ErrReservationConflict
+		}
+		return nil
+	}
+	sb := unsafe.Slice((*byte)(hdr.sbp), hdr.sbLenWr)
+	sense, perr := ParseSense(sb)
+	if perr != nil {
+		sense, perr = nil, fmt.Errorf("%w: %x", perr, sb)
+	}
+	log.Printf("scsi: cmd=%x status=%x host=%d driver=%d resid=%d sb=%x: %v\n",
+		unsafe.Slice((*byte)(hdr.cmdp), hdr.cmdLen),
+		hdr.status, hdr.hostStatus, hdr.driverStatus, hdr.resid, sb, sense)
+	if perr != nil {
+		return perr
+	}
+	return sense
+}
+
 func sgIO(fd uintptr, arg *sgioHdr) error {
 	return ioctl(fd, 0x2285, uintptr(unsafe.Pointer(arg)))
 }
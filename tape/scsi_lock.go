@@ -0,0 +1,98 @@
+package tape
+
+import (
+	"log"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+)
+
+// PREVENT field of PREVENT ALLOW MEDIUM REMOVAL
+const (
+	removalAllow   = 0b00
+	removalPrevent = 0b01
+)
+
+func (d Drive) preventAllow(prevent byte) error {
+	// sg nodes and replays have no st ioctls, they take the command itself
+	if !d.direct && !isSG(d.fd) && (d.state == nil || d.state.replay == nil) {
+		// through st, which otherwise unlocks on its own after errors and on close
+		if prevent == removalPrevent {
+			return d.MTLOCK()
+		}
+		return d.MTUNLOCK()
+	}
+	return d.scsiCmd([]byte{ScsiOpPreventAllowRemoval, 0, 0, 0, prevent, 0}, 60_000)
+}
+
+// PreventRemoval locks the medium in the drive, the eject button has no effect until AllowRemoval
+func (d Drive) PreventRemoval() error { return d.preventAllow(removalPrevent) }
+
+func (d Drive) AllowRemoval() error { return d.preventAllow(removalAllow) }
+
+// MediumLock is medium removal prevented for the length of a job
+type MediumLock struct {
+	d    Drive
+	sig  chan os.Signal
+	done chan struct{}
+	once sync.Once
+	err  error
+}
+
+// LockMedium prevents removal of the medium until Unlock. The drive keeps it prevented even
+// after the process exits, so SIGINT and SIGTERM unlock it too before the process dies of
+// the signal, running onSignal if not nil after unlocking.
+func (d Drive) LockMedium(onSignal func(os.Signal)) (*MediumLock, error) {
+	l := &MediumLock{
+		d:    d,
+		sig:  make(chan os.Signal, 1),
+		done: make(chan struct{}),
+	}
+	signal.Notify(l.sig, syscall.SIGINT, syscall.SIGTERM)
+	if err := d.PreventRemoval(); err != nil {
+		signal.Stop(l.sig)
+		return nil, err
+	}
+	go func() {
+		select {
+		case sig := <-l.sig:
+			log.Printf("%v: unlocking medium", sig)
+			if err := l.Unlock(); err != nil {
+				log.Println("unlocking medium:", err)
+			}
+			if onSignal != nil {
+				onSignal(sig)
+			}
+			signal.Reset(sig)
+			syscall.Kill(os.Getpid(), sig.(syscall.Signal))
+		case <-l.done:
+		}
+	}()
+	return l, nil
+}
+
+// Unlock allows medium removal again, only the first call has effect
+func (l *MediumLock) Unlock() error {
+	l.once.Do(func() {
+		signal.Stop(l.sig)
+		close(l.done)
+		l.err = l.d.AllowRemoval()
+	})
+	return l.err
+}
+
+// ForcedEject ejects a cartridge the drive fails to unload normally, by the vendor
+// MAINTENANCE OUT command of HP drives. Buffered data is lost and the cartridge may need
+// to be recovered by the drive before use. It is for an administrator freeing a stuck
+// cartridge, not for jobs.
+func (d Drive) ForcedEject() error {
+	if err := d.AllowRemoval(); err != nil {
+		log.Println("forced eject: allow removal:", err)
+	}
+	d.state.pos, d.state.partBlock = nil, nil
+	return d.scsiCmd([]byte{
+		byte(ScsiOpForcedEject >> 16), byte(ScsiOpForcedEject >> 8 & 0x1f), byte(ScsiOpForcedEject & 0xff),
+		0, 0, 0, 0, 0, 0, 0, 0, 0,
+	}, 15*60_000)
+}
@@ -0,0 +1,36 @@
+package tape
+
+import "testing"
+
+// TestPreventAllowSG checks the medium lock of a Drive opened without Direct goes over
+// SG_IO when there is no st behind it, as for force_eject on the sg node
+func TestPreventAllowSG(t *testing.T) {
+	preventAllow := func(prevent byte) TraceRecord {
+		return TraceRecord{CDB: []byte{ScsiOpPreventAllowRemoval, 0, 0, 0, prevent, 0}, Dir: sgDxferNone}
+	}
+	tests := []struct {
+		name string
+		op   func(Drive) error
+		cmds []TraceRecord
+	}{
+		{"allow", Drive.AllowRemoval, []TraceRecord{preventAllow(removalAllow)}},
+		{"prevent", Drive.PreventRemoval, []TraceRecord{preventAllow(removalPrevent)}},
+		{"forced eject", Drive.ForcedEject, []TraceRecord{
+			preventAllow(removalAllow),
+			{CDB: []byte{0xa4, 0x1f, 0x07, 0, 0, 0, 0, 0, 0, 0, 0, 0}, Dir: sgDxferNone},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &replay{trace: &Trace{Commands: tt.cmds}}
+			d := Drive{state: &driveState{notTape: true, replay: r}}
+			d.SetRecoveryPolicy(NoRecovery)
+			if err := tt.op(d); err != nil {
+				t.Fatal(err)
+			}
+			if r.next != len(tt.cmds) {
+				t.Fatalf("%d of %d commands issued", r.next, len(tt.cmds))
+			}
+		})
+	}
+}
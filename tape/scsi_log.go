@@ -0,0 +1,458 @@
+package tape
+
+import (
+	"encoding/binary"
+	"strings"
+)
+
+// ErrorCounterLog is LogPageWriteErrorCounters or LogPageReadErrorCounters
+type ErrorCounterLog struct {
+	CorrectedWithoutDelay  uint64
+	CorrectedWithDelay     uint64
+	TotalRetries           uint64 // rewrites or rereads
+	TotalCorrected         uint64
+	CorrectionAlgProcessed uint64
+	TotalBytesProcessed    uint64
+	TotalUncorrected       uint64
+}
+
+func ParseErrorCounterLog(lp LogPage) (l ErrorCounterLog) {
+	for _, p := range lp.Params {
+		v := p.Uint()
+		switch p.Code {
+		case 0x0000:
+			l.CorrectedWithoutDelay = v
+		case 0x0001:
+			l.CorrectedWithDelay = v
+		case 0x0002:
+			l.TotalRetries = v
+		case 0x0003:
+			l.TotalCorrected = v
+		case 0x0004:
+			l.CorrectionAlgProcessed = v
+		case 0x0005:
+			l.TotalBytesProcessed = v
+		case 0x0006:
+			l.TotalUncorrected = v
+		}
+	}
+	return
+}
+
+// SequentialAccessLog is LogPageSequentialAccessDevice, native capacities in MB
+type SequentialAccessLog struct {
+	BytesFromHost      uint64
+	BytesWrittenMedium uint64
+	BytesReadMedium    uint64
+	BytesToHost        uint64
+
+	NativeCapacityBOPToEOD  uint64
+	NativeCapacityBOPToEW   uint64
+	NativeCapacityEWToEOP   uint64
+	NativeCapacityBOPToCur  uint64
+	MaxNativeCapacityBuffer uint64
+	CleaningRequired        bool
+}
+
+func ParseSequentialAccessLog(lp LogPage) (l SequentialAccessLog) {
+	for _, p := range lp.Params {
+		v := p.Uint()
+		switch p.Code {
+		case 0x0000:
+			l.BytesFromHost = v
+		case 0x0001:
+			l.BytesWrittenMedium = v
+		case 0x0002:
+			l.BytesReadMedium = v
+		case 0x0003:
+			l.BytesToHost = v
+		case 0x0004:
+			l.NativeCapacityBOPToEOD = v
+		case 0x0005:
+			l.NativeCapacityBOPToEW = v
+		case 0x0006:
+			l.NativeCapacityEWToEOP = v
+		case 0x0007:
+			l.NativeCapacityBOPToCur = v
+		case 0x0008:
+			l.MaxNativeCapacityBuffer = v
+		case 0x0100:
+			l.CleaningRequired = v != 0
+		}
+	}
+	return
+}
+
+// TemperatureLog is LogPageTemperature in Celsius, -1 if not available
+type TemperatureLog struct {
+	Current   int
+	Reference int
+}
+
+func ParseTemperatureLog(lp LogPage) TemperatureLog {
+	l := TemperatureLog{Current: -1, Reference: -1}
+	for _, p := range lp.Params {
+		if len(p.Value) < 2 || p.Value[1] == 0xff {
+			continue
+		}
+		switch p.Code {
+		case 0x0000:
+			l.Current = int(p.Value[1])
+		case 0x0001:
+			l.Reference = int(p.Value[1])
+		}
+	}
+	return l
+}
+
+// TapeAlertFlags has bit n-1 set when TapeAlert flag n is active
+type TapeAlertFlags uint64
+
+func (f TapeAlertFlags) Has(flag int) bool {
+	return flag >= 1 && flag <= 64 && f&(1<<(flag-1)) != 0
+}
+
+func ParseTapeAlertLog(lp LogPage) (f TapeAlertFlags) {
+	for _, p := range lp.Params {
+		if p.Code >= 1 && p.Code <= 64 && p.Uint()&1 != 0 {
+			f |= 1 << (p.Code - 1)
+		}
+	}
+	return
+}
+
+// TapeUsageLog is LogPageTapeUsage as reported by LTO drives
+type TapeUsageLog struct {
+	ThreadCount          uint64
+	DataSetsWritten      uint64
+	WriteRetries         uint64
+	UnrecoveredWrites    uint64
+	SuspendedWrites      uint64
+	FatalSuspendedWrites uint64
+	DataSetsRead         uint64
+	ReadRetries          uint64
+	UnrecoveredReads     uint64
+	SuspendedReads       uint64
+	FatalSuspendedReads  uint64
+}
+
+func ParseTapeUsageLog(lp LogPage) (l TapeUsageLog) {
+	for _, p := range lp.Params {
+		v := p.Uint()
+		switch p.Code {
+		case 0x0001:
+			l.ThreadCount = v
+		case 0x0002:
+			l.DataSetsWritten = v
+		case 0x0003:
+			l.WriteRetries = v
+		case 0x0004:
+			l.UnrecoveredWrites = v
+		case 0x0005:
+			l.SuspendedWrites = v
+		case 0x0006:
+			l.FatalSuspendedWrites = v
+		case 0x0007:
+			l.DataSetsRead = v
+		case 0x0008:
+			l.ReadRetries = v
+		case 0x0009:
+			l.UnrecoveredReads = v
+		case 0x000a:
+			l.SuspendedReads = v
+		case 0x000b:
+			l.FatalSuspendedReads = v
+		}
+	}
+	return
+}
+
+// CapacityLog is LogPageTapeCapacity, indexed by partition
+type CapacityLog struct {
+	RemainingMB [2]uint64
+	MaximumMB   [2]uint64
+}
+
+func ParseCapacityLog(lp LogPage) (l CapacityLog) {
+	for _, p := range lp.Params {
+		v := p.Uint()
+		switch p.Code {
+		case 0x0001:
+			l.RemainingMB[0] = v
+		case 0x0002:
+			l.RemainingMB[1] = v
+		case 0x0003:
+			l.MaximumMB[0] = v
+		case 0x0004:
+			l.MaximumMB[1] = v
+		}
+	}
+	return
+}
+
+// CompressionLog is LogPageDataCompression or LogPageDataCompressionHP
+type CompressionLog struct {
+	ReadRatio   float64 // uncompressed/compressed
+	WriteRatio  float64
+	ToHostMB    uint64
+	ReadTapeMB  uint64
+	FromHostMB  uint64
+	WriteTapeMB uint64
+}
+
+func ParseCompressionLog(lp LogPage) (l CompressionLog) {
+	for _, p := range lp.Params {
+		v := p.Uint()
+		switch p.Code {
+		case 0x0000:
+			l.ReadRatio = float64(v) / 100
+		case 0x0001:
+			l.WriteRatio = float64(v) / 100
+		case 0x0002:
+			l.ToHostMB = v
+		case 0x0004:
+			l.ReadTapeMB = v
+		case 0x0006:
+			l.FromHostMB = v
+		case 0x0008:
+			l.WriteTapeMB = v
+		}
+	}
+	return
+}
+
+// VolumeStatisticsLog is LogPageVolumeStatistics
+type VolumeStatisticsLog struct {
+	Mounts                 uint64
+	DataSetsWritten        uint64
+	RecoveredWriteErrors   uint64
+	UnrecoveredWriteErrors uint64
+	DataSetsRead           uint64
+	RecoveredReadErrors    uint64
+	UnrecoveredReadErrors  uint64
+	LastMountMBWritten     uint64
+	LastMountMBRead        uint64
+	LifetimeMBWritten      uint64
+	LifetimeMBRead         uint64
+	TotalNativeCapacityMB  uint64
+	TotalUsedCapacityMB    uint64
+	SerialNumber           string
+	Barcode                string
+	Manufacturer           string
+	WriteProtect           bool
+	WORM                   bool
+	BOMPasses              uint64
+	MOMPasses              uint64
+	PartitionCapacityMB    map[uint16]uint64
+	PartitionUsedMB        map[uint16]uint64
+	PartitionRemainingToEW map[uint16]uint64
+}
+
+func parsePartitionRecords(v []byte) map[uint16]uint64 {
+	m := make(map[uint16]uint64)
+	for len(v) >= 4 {
+		l := int(v[0]) + 1
+		if l < 4 || len(v) < l {
+			break
+		}
+		var n uint64
+		for _, b := range v[4:l] {
+			n = n<<8 | uint64(b)
+		}
+		m[binary.BigEndian.Uint16(v[2:])] = n
+		v = v[l:]
+	}
+	return m
+}
+
+func ParseVolumeStatisticsLog(lp LogPage) (l VolumeStatisticsLog) {
+	for _, p := range lp.Params {
+		v := p.Uint()
+		switch p.Code {
+		case 0x0001:
+			l.Mounts = v
+		case 0x0002:
+			l.DataSetsWritten = v
+		case 0x0003:
+			l.RecoveredWriteErrors = v
+		case 0x0004:
+			l.UnrecoveredWriteErrors = v
+		case 0x0007:
+			l.DataSetsRead = v
+		case 0x0008:
+			l.RecoveredReadErrors = v
+		case 0x0009:
+			l.UnrecoveredReadErrors = v
+		case 0x000e:
+			l.LastMountMBWritten = v
+		case 0x000f:
+			l.LastMountMBRead = v
+		case 0x0010:
+			l.LifetimeMBWritten = v
+		case 0x0011:
+			l.LifetimeMBRead = v
+		case 0x0016:
+			l.TotalNativeCapacityMB = v
+		case 0x0017:
+			l.TotalUsedCapacityMB = v
+		case 0x0040:
+			l.SerialNumber = strings.TrimSpace(string(p.Value))
+		case 0x0042:
+			l.Barcode = strings.TrimSpace(string(p.Value))
+		case 0x0043:
+			l.Manufacturer = strings.TrimSpace(string(p.Value))
+		case 0x0080:
+			l.WriteProtect = v != 0
+		case 0x0081:
+			l.WORM = v != 0
+		case 0x0101:
+			l.BOMPasses = v
+		case 0x0102:
+			l.MOMPasses = v
+		case 0x0202:
+			l.PartitionCapacityMB = parsePartitionRecords(p.Value)
+		case 0x0203:
+			l.PartitionUsedMB = parsePartitionRecords(p.Value)
+		case 0x0204:
+			l.PartitionRemainingToEW = parsePartitionRecords(p.Value)
+		}
+	}
+	return
+}
+
+// DeviceStatisticsLog is LogPageDeviceStatistics
+type DeviceStatisticsLog struct {
+	LifetimeLoads           uint64
+	LifetimeCleanings       uint64
+	LifetimePowerOnHours    uint64
+	LifetimeMotionHours     uint64
+	LifetimeMetersProcessed uint64
+	MotionHoursSinceClean   uint64
+	LifetimePowerCycles     uint64
+	HardWriteErrors         uint64
+	HardReadErrors          uint64
+	SerialNumber            string
+	MediumRemovalPrevented  bool
+}
+
+func ParseDeviceStatisticsLog(lp LogPage) (l DeviceStatisticsLog) {
+	for _, p := range lp.Params {
+		v := p.Uint()
+		switch p.Code {
+		case 0x0000:
+			l.LifetimeLoads = v
+		case 0x0001:
+			l.LifetimeCleanings = v
+		case 0x0002:
+			l.LifetimePowerOnHours = v
+		case 0x0003:
+			l.LifetimeMotionHours = v
+		case 0x0004:
+			l.LifetimeMetersProcessed = v
+		case 0x0008:
+			l.MotionHoursSinceClean = v
+		case 0x000c:
+			l.LifetimePowerCycles = v
+		case 0x000e:
+			l.HardWriteErrors = v
+		case 0x000f:
+			l.HardReadErrors = v
+		case 0x0041:
+			l.SerialNumber = strings.TrimSpace(string(p.Value))
+		case 0x0080:
+			l.MediumRemovalPrevented = v != 0
+		}
+	}
+	return
+}
+
+// PerformanceLog is LogPagePerformance in the HPE LTO layout
+type PerformanceLog struct {
+	RepositionsPer100MB uint64
+	DataRateIntoBuffer  uint64
+	MaximumDataRate     uint64
+	CurrentDataRate     uint64
+	NativeDataRate      uint64
+	// Counters keeps every parameter, the page is vendor specific
+	Counters map[uint16]uint64
+}
+
+func ParsePerformanceLog(lp LogPage) (l PerformanceLog) {
+	l.Counters = make(map[uint16]uint64)
+	for _, p := range lp.Params {
+		v := p.Uint()
+		l.Counters[p.Code] = v
+		switch p.Code {
+		case 0x0000:
+			l.RepositionsPer100MB = v
+		case 0x0001:
+			l.DataRateIntoBuffer = v
+		case 0x0002:
+			l.MaximumDataRate = v
+		case 0x0003:
+			l.CurrentDataRate = v
+		case 0x0004:
+			l.NativeDataRate = v
+		}
+	}
+	return
+}
+
+func (d Drive) logCumulative(page byte) (LogPage, error) {
+	return d.LogSense(page, 0, LogPCCurrentCumulative)
+}
+
+func (d Drive) WriteErrorCounters() (ErrorCounterLog, error) {
+	lp, err := d.logCumulative(LogPageWriteErrorCounters)
+	return ParseErrorCounterLog(lp), err
+}
+
+func (d Drive) ReadErrorCounters() (ErrorCounterLog, error) {
+	lp, err := d.logCumulative(LogPageReadErrorCounters)
+	return ParseErrorCounterLog(lp), err
+}
+
+func (d Drive) SequentialAccess() (SequentialAccessLog, error) {
+	lp, err := d.logCumulative(LogPageSequentialAccessDevice)
+	return ParseSequentialAccessLog(lp), err
+}
+
+func (d Drive) Temperature() (TemperatureLog, error) {
+	lp, err := d.logCumulative(LogPageTemperature)
+	return ParseTemperatureLog(lp), err
+}
+
+func (d Drive) TapeAlertFlags() (TapeAlertFlags, error) {
+	lp, err := d.logCumulative(LogPageTapeAlert)
+	return ParseTapeAlertLog(lp), err
+}
+
+func (d Drive) TapeUsage() (TapeUsageLog, error) {
+	lp, err := d.logCumulative(LogPageTapeUsage)
+	return ParseTapeUsageLog(lp), err
+}
+
+func (d Drive) Capacity() (CapacityLog, error) {
+	lp, err := d.logCumulative(LogPageTapeCapacity)
+	return ParseCapacityLog(lp), err
+}
+
+func (d Drive) Compression() (CompressionLog, error) {
+	lp, err := d.logCumulative(LogPageDataCompression)
+	return ParseCompressionLog(lp), err
+}
+
+func (d Drive) VolumeStatistics() (VolumeStatisticsLog, error) {
+	lp, err := d.logCumulative(LogPageVolumeStatistics)
+	return ParseVolumeStatisticsLog(lp), err
+}
+
+func (d Drive) DeviceStatistics() (DeviceStatisticsLog, error) {
+	lp, err := d.logCumulative(LogPageDeviceStatistics)
+	return ParseDeviceStatisticsLog(lp), err
+}
+
+func (d Drive) Performance() (PerformanceLog, error) {
+	lp, err := d.logCumulative(LogPagePerformance)
+	return ParsePerformanceLog(lp), err
+}
@@ -0,0 +1,535 @@
+package tape
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// Page control of MODE SENSE
+const (
+	ModePCCurrent    = 0b00
+	ModePCChangeable = 0b01
+	ModePCDefault    = 0b10
+	ModePCSaved      = 0b11
+)
+
+const modeSenseMaxLen = 0xfffc
+
+type BlockDescriptor struct {
+	Density     byte
+	Blocks      uint32 // 0 means all remaining
+	BlockLength uint32 // 0 means variable
+}
+
+// ModeData is a MODE SENSE(10) response for a single page
+type ModeData struct {
+	MediumType     byte
+	DeviceSpecific byte // WP(7), BUFFERED MODE(6:4), SPEED(3:0)
+	Blocks         []BlockDescriptor
+	Page           []byte // including page header
+}
+
+func (md ModeData) WriteProtected() bool { return md.DeviceSpecific&0x80 != 0 }
+
+func (md ModeData) BufferedMode() byte { return md.DeviceSpecific >> 4 & 0b111 }
+
+func parseModeData(dat []byte) (md ModeData, err error) {
+	if len(dat) < 8 {
+		return md, errors.New("mode sense: short header")
+	}
+	if n := int(binary.BigEndian.Uint16(dat)) + 2; n < len(dat) {
+		dat = dat[:n]
+	}
+	md.MediumType = dat[2]
+	md.DeviceSpecific = dat[3]
+	longLBA := dat[4]&1 != 0
+	bdLen := int(binary.BigEndian.Uint16(dat[6:]))
+	if len(dat) < 8+bdLen {
+		return md, errors.New("mode sense: short block descriptors")
+	}
+	bd := dat[8 : 8+bdLen]
+	for {
+		if longLBA && len(bd) >= 16 {
+			md.Blocks = append(md.Blocks, BlockDescriptor{
+				Density:     bd[0],
+				Blocks:      uint32(binary.BigEndian.Uint64(bd)), // TODO 64bit blocks, unused by tape
+				BlockLength: binary.BigEndian.Uint32(bd[12:]),
+			})
+			bd = bd[16:]
+		} else if !longLBA && len(bd) >= 8 {
+			md.Blocks = append(md.Blocks, BlockDescriptor{
+				Density:     bd[0],
+				Blocks:      binary.BigEndian.Uint32(bd) & 0xffffff,
+				BlockLength: binary.BigEndian.Uint32(bd[4:]) & 0xffffff,
+			})
+			bd = bd[8:]
+		} else {
+			break
+		}
+	}
+	md.Page = dat[8+bdLen:]
+	return md, nil
+}
+
+// ModeSense reads page/subpage with page control pc
+func (d Drive) ModeSense(page, subpage, pc byte) (ModeData, error) {
+	dat, err := d.scsiRead([]byte{
+		ScsiOpModeSense10, 0,
+		pc<<6 | page&0x3f, subpage,
+		0, 0, 0,
+		byte(modeSenseMaxLen >> 8), byte(modeSenseMaxLen & 0xff), // bufLen
+		0,
+	}, modeSenseMaxLen, 60_000)
+	if err != nil {
+		return ModeData{}, err
+	}
+	return parseModeData(dat)
+}
+
+// ModeSelect sends md with its page, save asks the drive to keep it across power cycles
+func (d Drive) ModeSelect(md ModeData, save bool) error {
+	buf := make([]byte, 8, 8+8*len(md.Blocks)+len(md.Page))
+	buf[2] = md.MediumType
+	buf[3] = md.DeviceSpecific & 0x7f // WP is reserved for MODE SELECT
+	binary.BigEndian.PutUint16(buf[6:], uint16(8*len(md.Blocks)))
+	for _, bd := range md.Blocks {
+		buf = append(buf, bd.Density,
+			byte(bd.Blocks>>16), byte(bd.Blocks>>8), byte(bd.Blocks),
+			0,
+			byte(bd.BlockLength>>16), byte(bd.BlockLength>>8), byte(bd.BlockLength))
+	}
+	page := len(buf)
+	buf = append(buf, md.Page...)
+	if len(md.Page) > 0 {
+		buf[page] &^= 0x80 // PS is reserved for MODE SELECT
+	}
+	var sp byte
+	if save {
+		sp = 1
+	}
+	n := len(buf)
+	return d.scsiWrite([]byte{
+		ScsiOpModeSelect10, 0x10 | sp, // PF
+		0, 0, 0, 0, 0,
+		byte(n >> 8), byte(n),
+		0,
+	}, buf, 60_000)
+}
+
+// ModePage is a typed mode page that can be read by ReadModePage and written by WriteModePage
+type ModePage interface {
+	Code() (page, subpage byte)
+	decode(p []byte) error
+	encode() []byte
+}
+
+// pageBody returns the page content after page header and checks the code
+func pageBody(p []byte, page, subpage byte) ([]byte, error) {
+	if len(p) < 2 || p[0]&0x3f != page {
+		return nil, fmt.Errorf("mode page %#x: not returned", page)
+	}
+	if p[0]&0x40 != 0 { // SPF
+		if len(p) < 4 || p[1] != subpage {
+			return nil, fmt.Errorf("mode page %#x/%#x: not returned", page, subpage)
+		}
+		n := int(binary.BigEndian.Uint16(p[2:]))
+		return p[4:min(len(p), 4+n)], nil
+	}
+	n := int(p[1])
+	return p[2:min(len(p), 2+n)], nil
+}
+
+// rawPage keeps the page as read so that encoding preserves fields not decoded
+type rawPage []byte
+
+func (r *rawPage) keep(p []byte, page, subpage byte, minLen int) ([]byte, error) {
+	body, err := pageBody(p, page, subpage)
+	if err != nil {
+		return nil, err
+	}
+	if len(body) < minLen {
+		return nil, fmt.Errorf("mode page %#x: short page %d", page, len(body))
+	}
+	hdr := 2
+	if p[0]&0x40 != 0 {
+		hdr = 4
+	}
+	*r = append(rawPage(nil), p[:hdr+len(body)]...)
+	return (*r)[hdr:], nil
+}
+
+// body returns the writable page content of r, allocating a blank page of length n when r is empty
+func (r *rawPage) body(page, subpage byte, n int) []byte {
+	if len(*r) == 0 {
+		if subpage != 0 {
+			*r = make(rawPage, 4+n)
+			(*r)[0] = page | 0x40
+			(*r)[1] = subpage
+			binary.BigEndian.PutUint16((*r)[2:], uint16(n))
+		} else {
+			*r = make(rawPage, 2+n)
+			(*r)[0] = page
+			(*r)[1] = byte(n)
+		}
+	}
+	if (*r)[0]&0x40 != 0 {
+		return (*r)[4:]
+	}
+	return (*r)[2:]
+}
+
+// ReadModePage reads p with page control pc and returns the whole ModeData
+func (d Drive) ReadModePage(p ModePage, pc byte) (ModeData, error) {
+	page, subpage := p.Code()
+	md, err := d.ModeSense(page, subpage, pc)
+	if err != nil {
+		return md, err
+	}
+	return md, p.decode(md.Page)
+}
+
+// WriteModePage sends p with current block descriptors and buffered mode preserved
+func (d Drive) WriteModePage(p ModePage, save bool) error {
+	page, subpage := p.Code()
+	md, err := d.ModeSense(page, subpage, ModePCCurrent)
+	if err != nil {
+		return err
+	}
+	md.Page = p.encode()
+	return d.ModeSelect(md, save)
+}
+
+// ReadWriteErrorRecoveryPage is ModePageReadWriteErrorRecovery
+type ReadWriteErrorRecoveryPage struct {
+	TB                bool // transfer block on unrecovered error
+	EER               bool // enable early recovery
+	PER               bool // post error, report recovered errors
+	DTE               bool // disable transfer on error
+	DCR               bool // disable correction
+	ReadRetryCount    byte
+	WriteRetryCount   byte
+	RecoveryTimeLimit uint16
+
+	raw rawPage
+}
+
+func (p *ReadWriteErrorRecoveryPage) Code() (byte, byte) { return ModePageReadWriteErrorRecovery, 0 }
+
+func (p *ReadWriteErrorRecoveryPage) decode(b []byte) error {
+	body, err := p.raw.keep(b, ModePageReadWriteErrorRecovery, 0, 10)
+	if err != nil {
+		return err
+	}
+	p.TB = body[0]&0x20 != 0
+	p.EER = body[0]&0x08 != 0
+	p.PER = body[0]&0x04 != 0
+	p.DTE = body[0]&0x02 != 0
+	p.DCR = body[0]&0x01 != 0
+	p.ReadRetryCount = body[1]
+	p.WriteRetryCount = body[6]
+	p.RecoveryTimeLimit = binary.BigEndian.Uint16(body[8:])
+	return nil
+}
+
+func (p *ReadWriteErrorRecoveryPage) encode() []byte {
+	body := p.raw.body(ModePageReadWriteErrorRecovery, 0, 10)
+	body[0] = setBit(body[0], 0x20, p.TB)
+	body[0] = setBit(body[0], 0x08, p.EER)
+	body[0] = setBit(body[0], 0x04, p.PER)
+	body[0] = setBit(body[0], 0x02, p.DTE)
+	body[0] = setBit(body[0], 0x01, p.DCR)
+	body[1] = p.ReadRetryCount
+	body[6] = p.WriteRetryCount
+	binary.BigEndian.PutUint16(body[8:], p.RecoveryTimeLimit)
+	return p.raw
+}
+
+// DataCompressionPage is ModePageDataCompression
+type DataCompressionPage struct {
+	DCE                    bool // data compression enable
+	DCC                    bool // data compression capable
+	DDE                    bool // data decompression enable
+	CompressionAlgorithm   uint32
+	DecompressionAlgorithm uint32
+
+	raw rawPage
+}
+
+func (p *DataCompressionPage) Code() (byte, byte) { return ModePageDataCompression, 0 }
+
+func (p *DataCompressionPage) decode(b []byte) error {
+	body, err := p.raw.keep(b, ModePageDataCompression, 0, 10)
+	if err != nil {
+		return err
+	}
+	p.DCE = body[0]&0x80 != 0
+	p.DCC = body[0]&0x40 != 0
+	p.DDE = body[1]&0x80 != 0
+	p.CompressionAlgorithm = binary.BigEndian.Uint32(body[2:])
+	p.DecompressionAlgorithm = binary.BigEndian.Uint32(body[6:])
+	return nil
+}
+
+func (p *DataCompressionPage) encode() []byte {
+	body := p.raw.body(ModePageDataCompression, 0, 14)
+	body[0] = setBit(body[0], 0x80, p.DCE)
+	body[1] = setBit(body[1], 0x80, p.DDE)
+	binary.BigEndian.PutUint32(body[2:], p.CompressionAlgorithm)
+	binary.BigEndian.PutUint32(body[6:], p.DecompressionAlgorithm)
+	return p.raw
+}
+
+// DeviceConfigurationPage is ModePageDeviceConfiguration
+type DeviceConfigurationPage struct {
+	ActiveFormat      byte
+	ActivePartition   byte
+	WriteDelayTime    uint16 // 100ms units
+	REW               bool   // report early warning
+	EODDefined        byte
+	SEW               bool // synchronize at early warning
+	BufferSizeAtEW    uint32
+	SelectCompression byte // 0 none, 1 default algorithm
+
+	raw rawPage
+}
+
+func (p *DeviceConfigurationPage) Code() (byte, byte) { return ModePageDeviceConfiguration, 0 }
+
+func (p *DeviceConfigurationPage) decode(b []byte) error {
+	body, err := p.raw.keep(b, ModePageDeviceConfiguration, 0, 13)
+	if err != nil {
+		return err
+	}
+	p.ActiveFormat = body[0] & 0x1f
+	p.ActivePartition = body[1]
+	p.WriteDelayTime = binary.BigEndian.Uint16(body[4:])
+	p.REW = body[6]&0x01 != 0
+	p.EODDefined = body[8] >> 5
+	p.SEW = body[8]&0x08 != 0
+	p.BufferSizeAtEW = uint32(body[9])<<16 | uint32(body[10])<<8 | uint32(body[11])
+	p.SelectCompression = body[12]
+	return nil
+}
+
+func (p *DeviceConfigurationPage) encode() []byte {
+	body := p.raw.body(ModePageDeviceConfiguration, 0, 14)
+	body[0] = body[0]&^0x1f | p.ActiveFormat&0x1f
+	body[1] = p.ActivePartition
+	binary.BigEndian.PutUint16(body[4:], p.WriteDelayTime)
+	body[6] = setBit(body[6], 0x01, p.REW)
+	body[8] = body[8]&0x1f | p.EODDefined<<5
+	body[8] = setBit(body[8], 0x08, p.SEW)
+	body[9], body[10], body[11] = byte(p.BufferSizeAtEW>>16), byte(p.BufferSizeAtEW>>8), byte(p.BufferSizeAtEW)
+	body[12] = p.SelectCompression
+	return p.raw
+}
+
+// MediumPartitionPage is ModePageMediumPartitions
+type MediumPartitionPage struct {
+	MaxAdditionalPartitions byte
+	AdditionalPartitions    byte // partitions defined - 1
+	FDP                     bool // fixed data partitions
+	SDP                     bool // select data partitions
+	IDP                     bool // initiator defined partitions
+	PSUM                    byte // partition size unit of measure
+	POFM                    bool // partition on format
+	MediumFormatRecognition byte
+	PartitionUnits          byte // size is in 10^PartitionUnits bytes when PSUM is 3
+	Sizes                   []uint16
+
+	raw rawPage
+}
+
+const (
+	PSUMBytes     = 0b00
+	PSUMKilobytes = 0b01
+	PSUMMegabytes = 0b10
+	PSUMUnits     = 0b11
+)
+
+func (p *MediumPartitionPage) Code() (byte, byte) { return ModePageMediumPartitions, 0 }
+
+func (p *MediumPartitionPage) decode(b []byte) error {
+	body, err := p.raw.keep(b, ModePageMediumPartitions, 0, 6)
+	if err != nil {
+		return err
+	}
+	p.MaxAdditionalPartitions = body[0]
+	p.AdditionalPartitions = body[1]
+	p.FDP = body[2]&0x80 != 0
+	p.SDP = body[2]&0x40 != 0
+	p.IDP = body[2]&0x20 != 0
+	p.PSUM = body[2] >> 3 & 0b11
+	p.POFM = body[2]&0x04 != 0
+	p.MediumFormatRecognition = body[3]
+	p.PartitionUnits = body[4] & 0x0f
+	p.Sizes = p.Sizes[:0]
+	for s := body[6:]; len(s) >= 2; s = s[2:] {
+		p.Sizes = append(p.Sizes, binary.BigEndian.Uint16(s))
+	}
+	return nil
+}
+
+func (p *MediumPartitionPage) encode() []byte {
+	n := 6 + 2*len(p.Sizes)
+	body := p.raw.body(ModePageMediumPartitions, 0, n)
+	if len(body) != n {
+		// partition count changed, resize keeping the fixed part
+		fixed := append(rawPage{}, p.raw[:2+6]...)
+		fixed[1] = byte(n)
+		p.raw = append(fixed, make([]byte, 2*len(p.Sizes))...)
+		body = p.raw[2:]
+	}
+	body[0] = p.MaxAdditionalPartitions
+	body[1] = p.AdditionalPartitions
+	body[2] = setBit(body[2], 0x80, p.FDP)
+	body[2] = setBit(body[2], 0x40, p.SDP)
+	body[2] = setBit(body[2], 0x20, p.IDP)
+	body[2] = body[2]&^0x18 | p.PSUM&0b11<<3
+	body[2] = setBit(body[2], 0x04, p.POFM)
+	body[3] = p.MediumFormatRecognition
+	body[4] = body[4]&0xf0 | p.PartitionUnits&0x0f
+	for i, s := range p.Sizes {
+		binary.BigEndian.PutUint16(body[6+2*i:], s)
+	}
+	return p.raw
+}
+
+// ControlPage is ModePageControl
+type ControlPage struct {
+	DSense           bool   // report sense data in descriptor format
+	GLTSD            bool   // global logging target save disable
+	RLEC             bool   // report log exception condition
+	SWP              bool   // software write protect
+	BusyTimeout      uint16 // 100ms units
+	SelfTestDuration uint16 // seconds for extended self-test
+
+	raw rawPage
+}
+
+func (p *ControlPage) Code() (byte, byte) { return ModePageControl, 0 }
+
+func (p *ControlPage) decode(b []byte) error {
+	body, err := p.raw.keep(b, ModePageControl, 0, 10)
+	if err != nil {
+		return err
+	}
+	p.DSense = body[0]&0x04 != 0
+	p.GLTSD = body[0]&0x02 != 0
+	p.RLEC = body[0]&0x01 != 0
+	p.SWP = body[2]&0x08 != 0
+	p.BusyTimeout = binary.BigEndian.Uint16(body[6:])
+	p.SelfTestDuration = binary.BigEndian.Uint16(body[8:])
+	return nil
+}
+
+func (p *ControlPage) encode() []byte {
+	body := p.raw.body(ModePageControl, 0, 10)
+	body[0] = setBit(body[0], 0x04, p.DSense)
+	body[0] = setBit(body[0], 0x02, p.GLTSD)
+	body[0] = setBit(body[0], 0x01, p.RLEC)
+	body[2] = setBit(body[2], 0x08, p.SWP)
+	binary.BigEndian.PutUint16(body[6:], p.BusyTimeout)
+	return p.raw
+}
+
+// Logical block protection methods
+const (
+	LBPNone        = 0x00
+	LBPReedSolomon = 0x01 // Reed-Solomon CRC of ECMA-319
+	LBPCRC32C      = 0x02
+)
+
+// ControlDataProtectionPage is ModeSubpageControlDataProtection of ModePageControl
+type ControlDataProtectionPage struct {
+	Method     byte // LBPNone, ...
+	InfoLength byte // bytes of protection information per block, 4 for both methods
+	LBPW       bool // the drive checks the protection information of written blocks
+	LBPR       bool // the drive appends protection information to read blocks
+	RBDP       bool // the drive appends protection information to RECOVER BUFFERED DATA
+
+	raw rawPage
+}
+
+func (p *ControlDataProtectionPage) Code() (byte, byte) {
+	return ModePageControl, ModeSubpageControlDataProtection
+}
+
+func (p *ControlDataProtectionPage) decode(b []byte) error {
+	body, err := p.raw.keep(b, ModePageControl, ModeSubpageControlDataProtection, 3)
+	if err != nil {
+		return err
+	}
+	p.Method = body[0]
+	p.InfoLength = body[1] & 0x3f
+	p.LBPW = body[2]&0x80 != 0
+	p.LBPR = body[2]&0x40 != 0
+	p.RBDP = body[2]&0x20 != 0
+	return nil
+}
+
+func (p *ControlDataProtectionPage) encode() []byte {
+	body := p.raw.body(ModePageControl, ModeSubpageControlDataProtection, 28)
+	body[0] = p.Method
+	body[1] = body[1]&0xc0 | p.InfoLength&0x3f
+	body[2] = setBit(body[2], 0x80, p.LBPW)
+	body[2] = setBit(body[2], 0x40, p.LBPR)
+	body[2] = setBit(body[2], 0x20, p.RBDP)
+	return p.raw
+}
+
+// InformationExceptionsPage is ModePageInformationExceptions, controls TapeAlert reporting
+type InformationExceptionsPage struct {
+	Perf          bool
+	EBF           bool
+	EWASC         bool
+	DExcpt        bool // disable exception control
+	Test          bool
+	LogErr        bool
+	MRIE          byte // method of reporting informational exceptions
+	IntervalTimer uint32
+	ReportCount   uint32
+
+	raw rawPage
+}
+
+func (p *InformationExceptionsPage) Code() (byte, byte) { return ModePageInformationExceptions, 0 }
+
+func (p *InformationExceptionsPage) decode(b []byte) error {
+	body, err := p.raw.keep(b, ModePageInformationExceptions, 0, 10)
+	if err != nil {
+		return err
+	}
+	p.Perf = body[0]&0x80 != 0
+	p.EBF = body[0]&0x20 != 0
+	p.EWASC = body[0]&0x10 != 0
+	p.DExcpt = body[0]&0x08 != 0
+	p.Test = body[0]&0x04 != 0
+	p.LogErr = body[0]&0x01 != 0
+	p.MRIE = body[1] & 0x0f
+	p.IntervalTimer = binary.BigEndian.Uint32(body[2:])
+	p.ReportCount = binary.BigEndian.Uint32(body[6:])
+	return nil
+}
+
+func (p *InformationExceptionsPage) encode() []byte {
+	body := p.raw.body(ModePageInformationExceptions, 0, 10)
+	body[0] = setBit(body[0], 0x80, p.Perf)
+	body[0] = setBit(body[0], 0x20, p.EBF)
+	body[0] = setBit(body[0], 0x10, p.EWASC)
+	body[0] = setBit(body[0], 0x08, p.DExcpt)
+	body[0] = setBit(body[0], 0x04, p.Test)
+	body[0] = setBit(body[0], 0x01, p.LogErr)
+	body[1] = body[1]&0xf0 | p.MRIE&0x0f
+	binary.BigEndian.PutUint32(body[2:], p.IntervalTimer)
+	binary.BigEndian.PutUint32(body[6:], p.ReportCount)
+	return p.raw
+}
+
+func setBit(b, mask byte, v bool) byte {
+	if v {
+		return b | mask
+	}
+	return b &^ mask
+}
@@ -4,6 +4,7 @@ const (
 	ScsiOpTestUnitReady        = 0x00
 	ScsiOpRewind               = 0x01
 	ScsiOpRequestSense         = 0x03
+	ScsiOpInitElementStatus    = 0x07 // media changer
 	ScsiOpFormatMedium         = 0x04
 	ScsiOpReadBlockLimits      = 0x05
 	ScsiOpRead                 = 0x08
@@ -45,6 +46,8 @@ const (
 	ScsiOpReportLuns           = 0xA0
 	ScsiOpSecurityProtocolIn   = 0xA2
 	ScsiOpReportDeviceID       = 0xA3
+	ScsiOpMoveMedium           = 0xA5 // media changer
+	ScsiOpReadElementStatus    = 0xB8 // media changer
 
 	ScsiOpReportTargetPortGrp = 0xA30A
 	ScsiOpReportSupportedOp   = 0xA30C
@@ -102,6 +105,10 @@ const (
 	ModePageCDROMEmulation         = 0x3E
 
 	ModePageAllPages = 0x3F
+
+	ModeSubpageControlDataProtection = 0xF0 // of ModePageControl
+
+	ModePageElementAddress = 0x1D // media changer
 )
 
 const (
@@ -128,786 +135,3 @@ const (
 	LogPageDTDeviceError          = 0x35
 	LogPageDeviceStatus           = 0x3E
 )
-
-/*
-ASC/ASCQ DZTPROMAEBKVF  Description
--------- -------------  ----------------------------------------------------
-00h/00h  DZTPROMAEBKVF  NO ADDITIONAL SENSE INFORMATION
-00h/01h    T            FILEMARK DETECTED
-00h/02h    T            END-OF-PARTITION/MEDIUM DETECTED
-00h/03h    T            SETMARK DETECTED
-00h/04h    T            BEGINNING-OF-PARTITION/MEDIUM DETECTED
-00h/05h    T            END-OF-DATA DETECTED
-00h/06h  DZTPROMAEBKVF  I/O PROCESS TERMINATED
-00h/07h    T            PROGRAMMABLE EARLY WARNING DETECTED
-00h/11h      R          AUDIO PLAY OPERATION IN PROGRESS
-00h/12h      R          AUDIO PLAY OPERATION PAUSED
-00h/13h      R          AUDIO PLAY OPERATION SUCCESSFULLY COMPLETED
-00h/14h      R          AUDIO PLAY OPERATION STOPPED DUE TO ERROR
-00h/15h      R          NO CURRENT AUDIO STATUS TO RETURN
-00h/16h  DZTPROMAEBKVF  OPERATION IN PROGRESS
-00h/17h  DZT ROMAEBKVF  CLEANING REQUESTED
-00h/18h    T            ERASE OPERATION IN PROGRESS
-00h/19h    T            LOCATE OPERATION IN PROGRESS
-00h/1Ah    T            REWIND OPERATION IN PROGRESS
-00h/1Bh    T            SET CAPACITY OPERATION IN PROGRESS
-00h/1Ch    T            VERIFY OPERATION IN PROGRESS
-00h/1Dh  DZT      B     ATA PASS THROUGH INFORMATION AVAILABLE
-00h/1Eh  DZT R MAEBKV   CONFLICTING SA CREATION REQUEST
-00h/1Fh  DZT      B     LOGICAL UNIT TRANSITIONING TO ANOTHER POWER CONDITION
-00h/20h  DZTP     B     EXTENDED COPY INFORMATION AVAILABLE
-00h/21h  DZ             ATOMIC COMMAND ABORTED DUE TO ACA
-00h/22h  DZ             DEFERRED MICROCODE IS PENDING
-01h/00h  DZ   O   BK    NO INDEX/SECTOR SIGNAL
-02h/00h  DZ  RO   BK    NO SEEK COMPLETE
-03h/00h  DZT  O   BK    PERIPHERAL DEVICE WRITE FAULT
-03h/01h    T            NO WRITE CURRENT
-03h/02h    T            EXCESSIVE WRITE ERRORS
-04h/00h  DZTPROMAEBKVF  LOGICAL UNIT NOT READY, CAUSE NOT REPORTABLE
-04h/01h  DZTPROMAEBKVF  LOGICAL UNIT IS IN PROCESS OF BECOMING READY
-04h/02h  DZTPROMAEBKVF  LOGICAL UNIT NOT READY, INITIALIZING COMMAND REQUIRED
-04h/03h  DZTPROMAEBKVF  LOGICAL UNIT NOT READY, MANUAL INTERVENTION REQUIRED
-04h/04h  DZT RO   B     LOGICAL UNIT NOT READY, FORMAT IN PROGRESS
-04h/05h  DZT  O A BK F  LOGICAL UNIT NOT READY, REBUILD IN PROGRESS
-04h/06h  DZT  O A BK    LOGICAL UNIT NOT READY, RECALCULATION IN PROGRESS
-04h/07h  DZTPROMAEBKVF  LOGICAL UNIT NOT READY, OPERATION IN PROGRESS
-04h/08h      R          LOGICAL UNIT NOT READY, LONG WRITE IN PROGRESS
-04h/09h  DZTPROMAEBKVF  LOGICAL UNIT NOT READY, SELF-TEST IN PROGRESS
-04h/0Ah  DZTPROMAEBKVF  LOGICAL UNIT NOT ACCESSIBLE, ASYMMETRIC ACCESS STATE TRANSITION
-04h/0Bh  DZTPROMAEBKVF  LOGICAL UNIT NOT ACCESSIBLE, TARGET PORT IN STANDBY STATE
-04h/0Ch  DZTPROMAEBKVF  LOGICAL UNIT NOT ACCESSIBLE, TARGET PORT IN UNAVAILABLE STATE
-04h/0Dh              F  LOGICAL UNIT NOT READY, STRUCTURE CHECK REQUIRED
-04h/0Eh  DZT R MAEBKVF  LOGICAL UNIT NOT READY, SECURITY SESSION IN PROGRESS
-04h/10h  DZT ROM  B     LOGICAL UNIT NOT READY, AUXILIARY MEMORY NOT ACCESSIBLE
-04h/11h  DZT RO AEB VF  LOGICAL UNIT NOT READY, NOTIFY (ENABLE SPINUP) REQUIRED
-04h/12h        M    V   LOGICAL UNIT NOT READY, OFFLINE
-04h/13h  DZT R MAEBKV   LOGICAL UNIT NOT READY, SA CREATION IN PROGRESS
-04h/14h  DZ       B     LOGICAL UNIT NOT READY, SPACE ALLOCATION IN PROGRESS
-04h/15h        M        LOGICAL UNIT NOT READY, ROBOTICS DISABLED
-04h/16h        M        LOGICAL UNIT NOT READY, CONFIGURATION REQUIRED
-04h/17h        M        LOGICAL UNIT NOT READY, CALIBRATION REQUIRED
-04h/18h        M        LOGICAL UNIT NOT READY, A DOOR IS OPEN
-04h/19h        M        LOGICAL UNIT NOT READY, OPERATING IN SEQUENTIAL MODE
-04h/1Ah  DZ       B     LOGICAL UNIT NOT READY, START STOP UNIT COMMAND IN PROGRESS
-04h/1Bh  DZ       B     LOGICAL UNIT NOT READY, SANITIZE IN PROGRESS
-04h/1Ch  DZT   MAEB     LOGICAL UNIT NOT READY, ADDITIONAL POWER USE NOT YET GRANTED
-04h/1Dh  DZ             LOGICAL UNIT NOT READY, CONFIGURATION IN PROGRESS
-04h/1Eh  DZ             LOGICAL UNIT NOT READY, MICROCODE ACTIVATION REQUIRED
-04h/1Fh  DZTPROMAEBKVF  LOGICAL UNIT NOT READY, MICROCODE DOWNLOAD REQUIRED
-04h/20h  DZTPROMAEBKVF  LOGICAL UNIT NOT READY, LOGICAL UNIT RESET REQUIRED
-04h/21h  DZTPROMAEBKVF  LOGICAL UNIT NOT READY, HARD RESET REQUIRED
-04h/22h  DZTPROMAEBKVF  LOGICAL UNIT NOT READY, POWER CYCLE REQUIRED
-04h/23h  DZ             LOGICAL UNIT NOT READY, AFFILIATION REQUIRED
-04h/24h  DZ             DEPOPULATION IN PROGRESS
-04h/25h  DZ             DEPOPULATION RESTORATION IN PROGRESS
-05h/00h  DZT ROMAEBKVF  LOGICAL UNIT DOES NOT RESPOND TO SELECTION
-06h/00h  DZ  ROM  BK    NO REFERENCE POSITION FOUND
-07h/00h  DZT ROM  BK    MULTIPLE PERIPHERAL DEVICES SELECTED
-08h/00h  DZT ROMAEBKVF  LOGICAL UNIT COMMUNICATION FAILURE
-08h/01h  DZT ROMAEBKVF  LOGICAL UNIT COMMUNICATION TIME-OUT
-08h/02h  DZT ROMAEBKVF  LOGICAL UNIT COMMUNICATION PARITY ERROR
-08h/03h  DZT ROM  BK    LOGICAL UNIT COMMUNICATION CRC ERROR (ULTRA-DMA/32)
-08h/04h  DZTPRO    K    UNREACHABLE COPY TARGET
-09h/00h  DZT RO   B     TRACK FOLLOWING ERROR
-09h/01h      RO    K    TRACKING SERVO FAILURE
-09h/02h      RO    K    FOCUS SERVO FAILURE
-09h/03h      RO         SPINDLE SERVO FAILURE
-09h/04h  DZT RO   B     HEAD SELECT FAULT
-09h/05h  DZT RO   B     VIBRATION INDUCED TRACKING ERROR
-0Ah/00h  DZTPROMAEBKVF  ERROR LOG OVERFLOW
-0Bh/00h  DZTPROMAEBKVF  WARNING
-0Bh/01h  DZTPROMAEBKVF  WARNING - SPECIFIED TEMPERATURE EXCEEDED
-0Bh/02h  DZTPROMAEBKVF  WARNING - ENCLOSURE DEGRADED
-0Bh/03h  DZTPROMAEBKVF  WARNING - BACKGROUND SELF-TEST FAILED
-0Bh/04h  DZTPRO AEBKVF  WARNING - BACKGROUND PRE-SCAN DETECTED MEDIUM ERROR
-0Bh/05h  DZTPRO AEBKVF  WARNING - BACKGROUND MEDIUM SCAN DETECTED MEDIUM ERROR
-0Bh/06h  DZTPROMAEBKVF  WARNING - NON-VOLATILE CACHE NOW VOLATILE
-0Bh/07h  DZTPROMAEBKVF  WARNING - DEGRADED POWER TO NON-VOLATILE CACHE
-0Bh/08h  DZTPROMAEBKVF  WARNING - POWER LOSS EXPECTED
-0Bh/09h  DZ             WARNING - DEVICE STATISTICS NOTIFICATION ACTIVE
-0Bh/0Ah  DZTPROMAEBKV   WARNING - HIGH CRITICAL TEMPERATURE LIMIT EXCEEDED
-0Bh/0Bh  DZTPROMAEBKV   WARNING - LOW CRITICAL TEMPERATURE LIMIT EXCEEDED
-0Bh/0Ch  DZTPROMAEBKV   WARNING - HIGH OPERATING TEMPERATURE LIMIT EXCEEDED
-0Bh/0Dh  DZTPROMAEBKV   WARNING - LOW OPERATING TEMPERATURE LIMIT EXCEEDED
-0Bh/0Eh  DZTPROMAEBKV   WARNING - HIGH CRITICAL HUMIDITY LIMIT EXCEEDED
-0Bh/0Fh  DZTPROMAEBKV   WARNING - LOW CRITICAL HUMIDITY LIMIT EXCEEDED
-0Bh/10h  DZTPROMAEBKV   WARNING - HIGH OPERATING HUMIDITY LIMIT EXCEEDED
-0Bh/11h  DZTPROMAEBKV   WARNING - LOW OPERATING HUMIDITY LIMIT EXCEEDED
-0Bh/12h  DZTPROMAEBKVF  WARNING - MICROCODE SECURITY AT RISK
-0Bh/13h  DZTPROMAEBKVF  WARNING - MICROCODE DIGITAL SIGNATURE VALIDATION FAILURE
-0Bh/14h  DZ             WARNING - PHYSICAL ELEMENT STATUS CHANGE
-0Ch/00h  DZT R          WRITE ERROR
-0Ch/01h  DZ        K    WRITE ERROR - RECOVERED WITH AUTO REALLOCATION
-0Ch/02h  DZ   O   BK    WRITE ERROR - AUTO REALLOCATION FAILED
-0Ch/03h  DZ   O   BK    WRITE ERROR - RECOMMEND REASSIGNMENT
-0Ch/04h  DZT  O   B     COMPRESSION CHECK MISCOMPARE ERROR
-0Ch/05h  DZT  O   B     DATA EXPANSION OCCURRED DURING COMPRESSION
-0Ch/06h  DZT  O   B     BLOCK NOT COMPRESSIBLE
-0Ch/07h  DZ  R          WRITE ERROR - RECOVERY NEEDED
-0Ch/08h      R          WRITE ERROR - RECOVERY FAILED
-0Ch/09h      R          WRITE ERROR - LOSS OF STREAMING
-0Ch/0Ah      R          WRITE ERROR - PADDING BLOCKS ADDED
-0Ch/0Bh  DZT ROM  B     AUXILIARY MEMORY WRITE ERROR
-0Ch/0Ch  DZTPRO AEBKVF  WRITE ERROR - UNEXPECTED UNSOLICITED DATA
-0Ch/0Dh  DZTPRO AEBKVF  WRITE ERROR - NOT ENOUGH UNSOLICITED DATA
-0Ch/0Eh  DZT  O   BK    MULTIPLE WRITE ERRORS
-0Ch/0Fh      R          DEFECTS IN ERROR WINDOW
-0Ch/10h  DZ             INCOMPLETE MULTIPLE ATOMIC WRITE OPERATIONS
-0Ch/11h  DZ             WRITE ERROR - RECOVERY SCAN NEEDED
-0Ch/12h  DZ             WRITE ERROR - INSUFFICIENT ZONE RESOURCES
-0Dh/00h  DZTPRO A  K    ERROR DETECTED BY THIRD PARTY TEMPORARY INITIATOR
-0Dh/01h  DZTPRO A  K    THIRD PARTY DEVICE FAILURE
-0Dh/02h  DZTPRO A  K    COPY TARGET DEVICE NOT REACHABLE
-0Dh/03h  DZTPRO A  K    INCORRECT COPY TARGET DEVICE TYPE
-0Dh/04h  DZTPRO A  K    COPY TARGET DEVICE DATA UNDERRUN
-0Dh/05h  DZTPRO A  K    COPY TARGET DEVICE DATA OVERRUN
-0Eh/00h  DZTPROMAEBK F  INVALID INFORMATION UNIT
-0Eh/01h  DZTPROMAEBK F  INFORMATION UNIT TOO SHORT
-0Eh/02h  DZTPROMAEBK F  INFORMATION UNIT TOO LONG
-0Eh/03h  DZTPR MAEBK F  INVALID FIELD IN COMMAND INFORMATION UNIT
-0Fh/00h
-10h/00h  DZ   O   BK    ID CRC OR ECC ERROR
-10h/01h  DZT  O         LOGICAL BLOCK GUARD CHECK FAILED
-10h/02h  DZT  O         LOGICAL BLOCK APPLICATION TAG CHECK FAILED
-10h/03h  DZT  O         LOGICAL BLOCK REFERENCE TAG CHECK FAILED
-10h/04h    T            LOGICAL BLOCK PROTECTION ERROR ON RECOVER BUFFERED DATA
-10h/05h    T            LOGICAL BLOCK PROTECTION METHOD ERROR
-11h/00h  DZT RO   BK    UNRECOVERED READ ERROR
-11h/01h  DZT RO   BK    READ RETRIES EXHAUSTED
-11h/02h  DZT RO   BK    ERROR TOO LONG TO CORRECT
-11h/03h  DZT  O   BK    MULTIPLE READ ERRORS
-11h/04h  DZ   O   BK    UNRECOVERED READ ERROR - AUTO REALLOCATE FAILED
-11h/05h      RO   B     L-EC UNCORRECTABLE ERROR
-11h/06h      RO   B     CIRC UNRECOVERED ERROR
-11h/07h       O   B     DATA RE-SYNCHRONIZATION ERROR
-11h/08h    T            INCOMPLETE BLOCK READ
-11h/09h    T            NO GAP FOUND
-11h/0Ah  DZT  O   BK    MISCORRECTED ERROR
-11h/0Bh  DZ   O   BK    UNRECOVERED READ ERROR - RECOMMEND REASSIGNMENT
-11h/0Ch  DZ   O   BK    UNRECOVERED READ ERROR - RECOMMEND REWRITE THE DATA
-11h/0Dh  DZT RO   B     DE-COMPRESSION CRC ERROR
-11h/0Eh  DZT RO   B     CANNOT DECOMPRESS USING DECLARED ALGORITHM
-11h/0Fh      R          ERROR READING UPC/EAN NUMBER
-11h/10h      R          ERROR READING ISRC NUMBER
-11h/11h      R          READ ERROR - LOSS OF STREAMING
-11h/12h  DZT ROM  B     AUXILIARY MEMORY READ ERROR
-11h/13h  DZTPRO AEBKVF  READ ERROR - FAILED RETRANSMISSION REQUEST
-11h/14h  DZ             READ ERROR - LBA MARKED BAD BY APPLICATION CLIENT
-11h/15h  DZ             WRITE AFTER SANITIZE REQUIRED
-12h/00h  DZ   O   BK    ADDRESS MARK NOT FOUND FOR ID FIELD
-13h/00h  DZ   O   BK    ADDRESS MARK NOT FOUND FOR DATA FIELD
-14h/00h  DZT RO   BK    RECORDED ENTITY NOT FOUND
-14h/01h  DZT RO   BK    RECORD NOT FOUND
-14h/02h    T            FILEMARK OR SETMARK NOT FOUND
-14h/03h    T            END-OF-DATA NOT FOUND
-14h/04h    T            BLOCK SEQUENCE ERROR
-14h/05h  DZT  O   BK    RECORD NOT FOUND - RECOMMEND REASSIGNMENT
-14h/06h  DZT  O   BK    RECORD NOT FOUND - DATA AUTO-REALLOCATED
-14h/07h    T            LOCATE OPERATION FAILURE
-15h/00h  DZT ROM  BK    RANDOM POSITIONING ERROR
-15h/01h  DZT ROM  BK    MECHANICAL POSITIONING ERROR
-15h/02h  DZT RO   BK    POSITIONING ERROR DETECTED BY READ OF MEDIUM
-16h/00h  DZ   O   BK    DATA SYNCHRONIZATION MARK ERROR
-16h/01h  DZ   O   BK    DATA SYNC ERROR - DATA REWRITTEN
-16h/02h  DZ   O   BK    DATA SYNC ERROR - RECOMMEND REWRITE
-16h/03h  DZ   O   BK    DATA SYNC ERROR - DATA AUTO-REALLOCATED
-16h/04h  DZ   O   BK    DATA SYNC ERROR - RECOMMEND REASSIGNMENT
-17h/00h  DZT RO   BK    RECOVERED DATA WITH NO ERROR CORRECTION APPLIED
-17h/01h  DZT RO   BK    RECOVERED DATA WITH RETRIES
-17h/02h  DZT RO   BK    RECOVERED DATA WITH POSITIVE HEAD OFFSET
-17h/03h  DZT RO   BK    RECOVERED DATA WITH NEGATIVE HEAD OFFSET
-17h/04h      RO   B     RECOVERED DATA WITH RETRIES AND/OR CIRC APPLIED
-17h/05h  DZ  RO   BK    RECOVERED DATA USING PREVIOUS SECTOR ID
-17h/06h  DZ   O   BK    RECOVERED DATA WITHOUT ECC - DATA AUTO-REALLOCATED
-17h/07h  DZ  RO   BK    RECOVERED DATA WITHOUT ECC - RECOMMEND REASSIGNMENT
-17h/08h  DZ  RO   BK    RECOVERED DATA WITHOUT ECC - RECOMMEND REWRITE
-17h/09h  DZ  RO   BK    RECOVERED DATA WITHOUT ECC - DATA REWRITTEN
-18h/00h  DZT RO   BK    RECOVERED DATA WITH ERROR CORRECTION APPLIED
-18h/01h  DZ  RO   BK    RECOVERED DATA WITH ERROR CORR. & RETRIES APPLIED
-18h/02h  DZ  RO   BK    RECOVERED DATA - DATA AUTO-REALLOCATED
-18h/03h      R          RECOVERED DATA WITH CIRC
-18h/04h      R          RECOVERED DATA WITH L-EC
-18h/05h  DZ  RO   BK    RECOVERED DATA - RECOMMEND REASSIGNMENT
-18h/06h  DZ  RO   BK    RECOVERED DATA - RECOMMEND REWRITE
-18h/07h  DZ   O   BK    RECOVERED DATA WITH ECC - DATA REWRITTEN
-18h/08h      R          RECOVERED DATA WITH LINKING
-19h/00h  DZ   O    K    DEFECT LIST ERROR
-19h/01h  DZ   O    K    DEFECT LIST NOT AVAILABLE
-19h/02h  DZ   O    K    DEFECT LIST ERROR IN PRIMARY LIST
-19h/03h  DZ   O    K    DEFECT LIST ERROR IN GROWN LIST
-1Ah/00h  DZTPROMAEBKVF  PARAMETER LIST LENGTH ERROR
-1Bh/00h  DZTPROMAEBKVF  SYNCHRONOUS DATA TRANSFER ERROR
-1Ch/00h  DZ   O   BK    DEFECT LIST NOT FOUND
-1Ch/01h  DZ   O   BK    PRIMARY DEFECT LIST NOT FOUND
-1Ch/02h  DZ   O   BK    GROWN DEFECT LIST NOT FOUND
-1Dh/00h  DZT RO   BK    MISCOMPARE DURING VERIFY OPERATION
-1Dh/01h  DZ       B     MISCOMPARE VERIFY OF UNMAPPED LBA
-1Eh/00h  DZ   O   BK    RECOVERED ID WITH ECC CORRECTION
-1Fh/00h  DZ   O    K    PARTIAL DEFECT LIST TRANSFER
-20h/00h  DZTPROMAEBKVF  INVALID COMMAND OPERATION CODE
-20h/01h  DZTPROMAEBK    ACCESS DENIED - INITIATOR PENDING-ENROLLED
-20h/02h  DZTPROMAEBK    ACCESS DENIED - NO ACCESS RIGHTS
-20h/03h  DZTPROMAEBK    ACCESS DENIED - INVALID MGMT ID KEY
-20h/04h    T            ILLEGAL COMMAND WHILE IN WRITE CAPABLE STATE
-20h/05h    T            Obsolete
-20h/06h    T            ILLEGAL COMMAND WHILE IN EXPLICIT ADDRESS MODE
-20h/07h    T            ILLEGAL COMMAND WHILE IN IMPLICIT ADDRESS MODE
-20h/08h  DZTPROMAEBK    ACCESS DENIED - ENROLLMENT CONFLICT
-20h/09h  DZTPROMAEBK    ACCESS DENIED - INVALID LU IDENTIFIER
-20h/0Ah  DZTPROMAEBK    ACCESS DENIED - INVALID PROXY TOKEN
-20h/0Bh  DZTPROMAEBK    ACCESS DENIED - ACL LUN CONFLICT
-20h/0Ch    T            ILLEGAL COMMAND WHEN NOT IN APPEND-ONLY MODE
-20h/0Dh  D              NOT AN ADMINISTRATIVE LOGICAL UNIT
-20h/0Eh  D              NOT A SUBSIDIARY LOGICAL UNIT
-20h/0Fh  D              NOT A CONGLOMERATE LOGICAL UNIT
-21h/00h  DZT RO   BK    LOGICAL BLOCK ADDRESS OUT OF RANGE
-21h/01h  DZT ROM  BK    INVALID ELEMENT ADDRESS
-21h/02h      R          INVALID ADDRESS FOR WRITE
-21h/03h      R          INVALID WRITE CROSSING LAYER JUMP
-21h/04h  DZ             UNALIGNED WRITE COMMAND
-21h/05h  DZ             WRITE BOUNDARY VIOLATION
-21h/06h  DZ             ATTEMPT TO READ INVALID DATA
-21h/07h  DZ             READ BOUNDARY VIOLATION
-21h/08h  DZ             MISALIGNED WRITE COMMAND
-21h/09h  DZ             ATTEMPT TO ACCESS GAP ZONE
-22h/00h  DZ             ILLEGAL FUNCTION (USE 20 00, 24 00, OR 26 00)
-23h/00h  DZTP     B     INVALID TOKEN OPERATION, CAUSE NOT REPORTABLE
-23h/01h  DZTP     B     INVALID TOKEN OPERATION, UNSUPPORTED TOKEN TYPE
-23h/02h  DZTP     B     INVALID TOKEN OPERATION, REMOTE TOKEN USAGE NOT SUPPORTED
-23h/03h  DZTP     B     INVALID TOKEN OPERATION, REMOTE ROD TOKEN CREATION NOT SUPPORTED
-23h/04h  DZTP     B     INVALID TOKEN OPERATION, TOKEN UNKNOWN
-23h/05h  DZTP     B     INVALID TOKEN OPERATION, TOKEN CORRUPT
-23h/06h  DZTP     B     INVALID TOKEN OPERATION, TOKEN REVOKED
-23h/07h  DZTP     B     INVALID TOKEN OPERATION, TOKEN EXPIRED
-23h/08h  DZTP     B     INVALID TOKEN OPERATION, TOKEN CANCELLED
-23h/09h  DZTP     B     INVALID TOKEN OPERATION, TOKEN DELETED
-23h/0Ah  DZTP     B     INVALID TOKEN OPERATION, INVALID TOKEN LENGTH
-24h/00h  DZTPROMAEBKVF  INVALID FIELD IN CDB
-24h/01h  DZTPRO AEBKVF  CDB DECRYPTION ERROR
-24h/02h    T            Obsolete
-24h/03h    T            Obsolete
-24h/04h              F  SECURITY AUDIT VALUE FROZEN
-24h/05h              F  SECURITY WORKING KEY FROZEN
-24h/06h              F  NONCE NOT UNIQUE
-24h/07h              F  NONCE TIMESTAMP OUT OF RANGE
-24h/08h  DZT R MAEBKV   INVALID XCDB
-24h/09h  DZ             INVALID FAST FORMAT
-25h/00h  DZTPROMAEBKVF  LOGICAL UNIT NOT SUPPORTED
-26h/00h  DZTPROMAEBKVF  INVALID FIELD IN PARAMETER LIST
-26h/01h  DZTPROMAEBKVF  PARAMETER NOT SUPPORTED
-26h/02h  DZTPROMAEBKVF  PARAMETER VALUE INVALID
-26h/03h  DZTPROMAE K    THRESHOLD PARAMETERS NOT SUPPORTED
-26h/04h  DZTPROMAEBKVF  INVALID RELEASE OF PERSISTENT RESERVATION
-26h/05h  DZTPRO A BK    DATA DECRYPTION ERROR
-26h/06h  DZTPRO    K    TOO MANY TARGET DESCRIPTORS
-26h/07h  DZTPRO    K    UNSUPPORTED TARGET DESCRIPTOR TYPE CODE
-26h/08h  DZTPRO    K    TOO MANY SEGMENT DESCRIPTORS
-26h/09h  DZTPRO    K    UNSUPPORTED SEGMENT DESCRIPTOR TYPE CODE
-26h/0Ah  DZTPRO    K    UNEXPECTED INEXACT SEGMENT
-26h/0Bh  DZTPRO    K    INLINE DATA LENGTH EXCEEDED
-26h/0Ch  DZTPRO    K    INVALID OPERATION FOR COPY SOURCE OR DESTINATION
-26h/0Dh  DZTPRO    K    COPY SEGMENT GRANULARITY VIOLATION
-26h/0Eh  DZTPROMAEBK    INVALID PARAMETER WHILE PORT IS ENABLED
-26h/0Fh              F  INVALID DATA-OUT BUFFER INTEGRITY CHECK VALUE
-26h/10h    T            DATA DECRYPTION KEY FAIL LIMIT REACHED
-26h/11h    T            INCOMPLETE KEY-ASSOCIATED DATA SET
-26h/12h    T            VENDOR SPECIFIC KEY REFERENCE NOT FOUND
-26h/13h  D              APPLICATION TAG MODE PAGE IS INVALID
-26h/14h    T            TAPE STREAM MIRRORING PREVENTED
-26h/15h    T            COPY SOURCE OR COPY DESTINATION NOT AUTHORIZED
-26h/16h  DZ             FAST COPY NOT POSSIBLE
-27h/00h  DZT RO   BK    WRITE PROTECTED
-27h/01h  DZT RO   BK    HARDWARE WRITE PROTECTED
-27h/02h  DZT RO   BK    LOGICAL UNIT SOFTWARE WRITE PROTECTED
-27h/03h    T R          ASSOCIATED WRITE PROTECT
-27h/04h    T R          PERSISTENT WRITE PROTECT
-27h/05h    T R          PERMANENT WRITE PROTECT
-27h/06h      R       F  CONDITIONAL WRITE PROTECT
-27h/07h  DZ       B     SPACE ALLOCATION FAILED WRITE PROTECT
-27h/08h  DZ             ZONE IS READ ONLY
-28h/00h  DZTPROMAEBKVF  NOT READY TO READY CHANGE, MEDIUM MAY HAVE CHANGED
-28h/01h  DZT ROM  B     IMPORT OR EXPORT ELEMENT ACCESSED
-28h/02h      R          FORMAT-LAYER MAY HAVE CHANGED
-28h/03h        M        IMPORT/EXPORT ELEMENT ACCESSED, MEDIUM CHANGED
-29h/00h  DZTPROMAEBKVF  POWER ON, RESET, OR BUS DEVICE RESET OCCURRED
-29h/01h  DZTPROMAEBKVF  POWER ON OCCURRED
-29h/02h  DZTPROMAEBKVF  SCSI BUS RESET OCCURRED
-29h/03h  DZTPROMAEBKVF  BUS DEVICE RESET FUNCTION OCCURRED
-29h/04h  DZTPROMAEBKVF  DEVICE INTERNAL RESET
-29h/05h  DZTPROMAEBKVF  TRANSCEIVER MODE CHANGED TO SINGLE-ENDED
-29h/06h  DZTPROMAEBKVF  TRANSCEIVER MODE CHANGED TO LVD
-29h/07h  DZTPROMAEBKVF  I_T NEXUS LOSS OCCURRED
-2Ah/00h  DZT ROMAEBKVF  PARAMETERS CHANGED
-2Ah/01h  DZT ROMAEBKVF  MODE PARAMETERS CHANGED
-2Ah/02h  DZT ROMAE K    LOG PARAMETERS CHANGED
-2Ah/03h  DZTPROMAE K    RESERVATIONS PREEMPTED
-2Ah/04h  DZTPROMAE      RESERVATIONS RELEASED
-2Ah/05h  DZTPROMAE      REGISTRATIONS PREEMPTED
-2Ah/06h  DZTPROMAEBKVF  ASYMMETRIC ACCESS STATE CHANGED
-2Ah/07h  DZTPROMAEBKVF  IMPLICIT ASYMMETRIC ACCESS STATE TRANSITION FAILED
-2Ah/08h  DZT ROMAEBKVF  PRIORITY CHANGED
-2Ah/09h  DZ             CAPACITY DATA HAS CHANGED
-2Ah/0Ah  DZT            ERROR HISTORY I_T NEXUS CLEARED
-2Ah/0Bh  DZT            ERROR HISTORY SNAPSHOT RELEASED
-2Ah/0Ch              F  ERROR RECOVERY ATTRIBUTES HAVE CHANGED
-2Ah/0Dh    T            DATA ENCRYPTION CAPABILITIES CHANGED
-2Ah/10h  DZT   M E  V   TIMESTAMP CHANGED
-2Ah/11h    T            DATA ENCRYPTION PARAMETERS CHANGED BY ANOTHER I_T NEXUS
-2Ah/12h    T            DATA ENCRYPTION PARAMETERS CHANGED BY VENDOR SPECIFIC EVENT
-2Ah/13h    T            DATA ENCRYPTION KEY INSTANCE COUNTER HAS CHANGED
-2Ah/14h  DZT R MAEBKV   SA CREATION CAPABILITIES DATA HAS CHANGED
-2Ah/15h    T   M    V   MEDIUM REMOVAL PREVENTION PREEMPTED
-2Ah/16h  DZ             ZONE RESET WRITE POINTER RECOMMENDED
-2Bh/00h  DZTPRO    K    COPY CANNOT EXECUTE SINCE HOST CANNOT DISCONNECT
-2Ch/00h  DZTPROMAEBKVF  COMMAND SEQUENCE ERROR
-2Ch/01h                 TOO MANY WINDOWS SPECIFIED
-2Ch/02h                 INVALID COMBINATION OF WINDOWS SPECIFIED
-2Ch/03h      R          CURRENT PROGRAM AREA IS NOT EMPTY
-2Ch/04h      R          CURRENT PROGRAM AREA IS EMPTY
-2Ch/05h           B     ILLEGAL POWER CONDITION REQUEST
-2Ch/06h      R          PERSISTENT PREVENT CONFLICT
-2Ch/07h  DZTPROMAEBKVF  PREVIOUS BUSY STATUS
-2Ch/08h  DZTPROMAEBKVF  PREVIOUS TASK SET FULL STATUS
-2Ch/09h  DZTPROM EBKVF  PREVIOUS RESERVATION CONFLICT STATUS
-2Ch/0Ah              F  PARTITION OR COLLECTION CONTAINS USER OBJECTS
-2Ch/0Bh    T            NOT RESERVED
-2Ch/0Ch  DZ             ORWRITE GENERATION DOES NOT MATCH
-2Ch/0Dh  DZ             RESET WRITE POINTER NOT ALLOWED
-2Ch/0Eh  DZ             ZONE IS OFFLINE
-2Ch/0Fh  DZ             STREAM NOT OPEN
-2Ch/10h  DZ             UNWRITTEN DATA IN ZONE
-2Ch/11h  D              DESCRIPTOR FORMAT SENSE DATA REQUIRED
-2Ch/12h  DZ             ZONE IS INACTIVE
-2Ch/13h  DZTPROMAEBKVF  WELL KNOWN LOGICAL UNIT ACCESS REQUIRED
-2Dh/00h    T            OVERWRITE ERROR ON UPDATE IN PLACE
-2Eh/00h  DZ  ROM  B     INSUFFICIENT TIME FOR OPERATION
-2Eh/01h  DZ   OM  B     COMMAND TIMEOUT BEFORE PROCESSING
-2Eh/02h  DZ   OM  B     COMMAND TIMEOUT DURING PROCESSING
-2Eh/03h  DZ   OM  B     COMMAND TIMEOUT DURING PROCESSING DUE TO ERROR RECOVERY
-2Fh/00h  DZTPROMAEBKVF  COMMANDS CLEARED BY ANOTHER INITIATOR
-2Fh/01h  DZ             COMMANDS CLEARED BY POWER LOSS NOTIFICATION
-2Fh/02h  DZTPROMAEBKVF  COMMANDS CLEARED BY DEVICE SERVER
-2Fh/03h  DZTPROMAEBKVF  SOME COMMANDS CLEARED BY QUEUING LAYER EVENT
-30h/00h  DZT ROM  BK    INCOMPATIBLE MEDIUM INSTALLED
-30h/01h  DZT RO   BK    CANNOT READ MEDIUM - UNKNOWN FORMAT
-30h/02h  DZT RO   BK    CANNOT READ MEDIUM - INCOMPATIBLE FORMAT
-30h/03h  DZT R M   K    CLEANING CARTRIDGE INSTALLED
-30h/04h  DZT RO   BK    CANNOT WRITE MEDIUM - UNKNOWN FORMAT
-30h/05h  DZT RO   BK    CANNOT WRITE MEDIUM - INCOMPATIBLE FORMAT
-30h/06h  DZT RO   B     CANNOT FORMAT MEDIUM - INCOMPATIBLE MEDIUM
-30h/07h  DZT ROMAEBKVF  CLEANING FAILURE
-30h/08h      R          CANNOT WRITE - APPLICATION CODE MISMATCH
-30h/09h      R          CURRENT SESSION NOT FIXATED FOR APPEND
-30h/0Ah  DZT RO AEBK    CLEANING REQUEST REJECTED
-30h/0Ch    T            WORM MEDIUM - OVERWRITE ATTEMPTED
-30h/0Dh    T            WORM MEDIUM - INTEGRITY CHECK
-30h/10h      R          MEDIUM NOT FORMATTED
-30h/11h        M        INCOMPATIBLE VOLUME TYPE
-30h/12h        M        INCOMPATIBLE VOLUME QUALIFIER
-30h/13h        M        CLEANING VOLUME EXPIRED
-31h/00h  DZT RO   BK    MEDIUM FORMAT CORRUPTED
-31h/01h  DZ  RO   B     FORMAT COMMAND FAILED
-31h/02h      R          ZONED FORMATTING FAILED DUE TO SPARE LINKING
-31h/03h  DZ       B     SANITIZE COMMAND FAILED
-31h/04h  DZ             DEPOPULATION FAILED
-31h/05h  DZ             DEPOPULATION RESTORATION FAILED
-32h/00h  DZ   O   BK    NO DEFECT SPARE LOCATION AVAILABLE
-32h/01h  DZ   O   BK    DEFECT LIST UPDATE FAILURE
-33h/00h    T            TAPE LENGTH ERROR
-34h/00h  DZTPROMAEBKVF  ENCLOSURE FAILURE
-35h/00h  DZTPROMAEBKVF  ENCLOSURE SERVICES FAILURE
-35h/01h  DZTPROMAEBKVF  UNSUPPORTED ENCLOSURE FUNCTION
-35h/02h  DZTPROMAEBKVF  ENCLOSURE SERVICES UNAVAILABLE
-35h/03h  DZTPROMAEBKVF  ENCLOSURE SERVICES TRANSFER FAILURE
-35h/04h  DZTPROMAEBKVF  ENCLOSURE SERVICES TRANSFER REFUSED
-35h/05h  DZT ROMAEBKVF  ENCLOSURE SERVICES CHECKSUM ERROR
-36h/00h                 RIBBON, INK, OR TONER FAILURE
-37h/00h  DZT ROMAEBKVF  ROUNDED PARAMETER
-38h/00h           B     EVENT STATUS NOTIFICATION
-38h/02h           B     ESN - POWER MANAGEMENT CLASS EVENT
-38h/04h           B     ESN - MEDIA CLASS EVENT
-38h/06h           B     ESN - DEVICE BUSY CLASS EVENT
-38h/07h  DZ             THIN PROVISIONING SOFT THRESHOLD REACHED
-39h/00h  DZT ROMAE K    SAVING PARAMETERS NOT SUPPORTED
-3Ah/00h  DZT ROM  BK    MEDIUM NOT PRESENT
-3Ah/01h  DZT ROM  BK    MEDIUM NOT PRESENT - TRAY CLOSED
-3Ah/02h  DZT ROM  BK    MEDIUM NOT PRESENT - TRAY OPEN
-3Ah/03h  DZT ROM  B     MEDIUM NOT PRESENT - LOADABLE
-3Ah/04h  DZT RO   B     MEDIUM NOT PRESENT - MEDIUM AUXILIARY MEMORY ACCESSIBLE
-3Bh/00h    T            SEQUENTIAL POSITIONING ERROR
-3Bh/01h    T            TAPE POSITION ERROR AT BEGINNING-OF-MEDIUM
-3Bh/02h    T            TAPE POSITION ERROR AT END-OF-MEDIUM
-3Bh/03h                 TAPE OR ELECTRONIC VERTICAL FORMS UNIT NOT READY
-3Bh/04h                 SLEW FAILURE
-3Bh/05h                 PAPER JAM
-3Bh/06h                 FAILED TO SENSE TOP-OF-FORM
-3Bh/07h                 FAILED TO SENSE BOTTOM-OF-FORM
-3Bh/08h    T            REPOSITION ERROR
-3Bh/09h                 READ PAST END OF MEDIUM
-3Bh/0Ah                 READ PAST BEGINNING OF MEDIUM
-3Bh/0Bh                 POSITION PAST END OF MEDIUM
-3Bh/0Ch    T            POSITION PAST BEGINNING OF MEDIUM
-3Bh/0Dh  DZT ROM  BK    MEDIUM DESTINATION ELEMENT FULL
-3Bh/0Eh  DZT ROM  BK    MEDIUM SOURCE ELEMENT EMPTY
-3Bh/0Fh      R          END OF MEDIUM REACHED
-3Bh/11h  DZT ROM  BK    MEDIUM MAGAZINE NOT ACCESSIBLE
-3Bh/12h  DZT ROM  BK    MEDIUM MAGAZINE REMOVED
-3Bh/13h  DZT ROM  BK    MEDIUM MAGAZINE INSERTED
-3Bh/14h  DZT ROM  BK    MEDIUM MAGAZINE LOCKED
-3Bh/15h  DZT ROM  BK    MEDIUM MAGAZINE UNLOCKED
-3Bh/16h      R          MECHANICAL POSITIONING OR CHANGER ERROR
-3Bh/17h              F  READ PAST END OF USER OBJECT
-3Bh/18h        M        ELEMENT DISABLED
-3Bh/19h        M        ELEMENT ENABLED
-3Bh/1Ah        M        DATA TRANSFER DEVICE REMOVED
-3Bh/1Bh        M        DATA TRANSFER DEVICE INSERTED
-3Bh/1Ch    T            TOO MANY LOGICAL OBJECTS ON PARTITION TO SUPPORT OPERATION
-3Bh/20h        M        ELEMENT STATIC INFORMATION CHANGED
-3Ch/00h
-3Dh/00h  DZTPROMAE K    INVALID BITS IN IDENTIFY MESSAGE
-3Eh/00h  DZTPROMAEBKVF  LOGICAL UNIT HAS NOT SELF-CONFIGURED YET
-3Eh/01h  DZTPROMAEBKVF  LOGICAL UNIT FAILURE
-3Eh/02h  DZTPROMAEBKVF  TIMEOUT ON LOGICAL UNIT
-3Eh/03h  DZTPROMAEBKVF  LOGICAL UNIT FAILED SELF-TEST
-3Eh/04h  DZTPROMAEBKVF  LOGICAL UNIT UNABLE TO UPDATE SELF-TEST LOG
-3Fh/00h  DZTPROMAEBKVF  TARGET OPERATING CONDITIONS HAVE CHANGED
-3Fh/01h  DZTPROMAEBKVF  MICROCODE HAS BEEN CHANGED
-3Fh/02h  DZTPROM  BK    CHANGED OPERATING DEFINITION
-3Fh/03h  DZTPROMAEBKVF  INQUIRY DATA HAS CHANGED
-3Fh/04h  DZT ROMAEBK    COMPONENT DEVICE ATTACHED
-3Fh/05h  DZT ROMAEBK    DEVICE IDENTIFIER CHANGED
-3Fh/06h  DZT ROMAEB     REDUNDANCY GROUP CREATED OR MODIFIED
-3Fh/07h  DZT ROMAEB     REDUNDANCY GROUP DELETED
-3Fh/08h  DZT ROMAEB     SPARE CREATED OR MODIFIED
-3Fh/09h  DZT ROMAEB     SPARE DELETED
-3Fh/0Ah  DZT ROMAEBK    VOLUME SET CREATED OR MODIFIED
-3Fh/0Bh  DZT ROMAEBK    VOLUME SET DELETED
-3Fh/0Ch  DZT ROMAEBK    VOLUME SET DEASSIGNED
-3Fh/0Dh  DZT ROMAEBK    VOLUME SET REASSIGNED
-3Fh/0Eh  DZTPROMAE      REPORTED LUNS DATA HAS CHANGED
-3Fh/0Fh  DZTPROMAEBKVF  ECHO BUFFER OVERWRITTEN
-3Fh/10h  DZT ROM  B     MEDIUM LOADABLE
-3Fh/11h  DZT ROM  B     MEDIUM AUXILIARY MEMORY ACCESSIBLE
-3Fh/12h  DZTPR MAEBK F  iSCSI IP ADDRESS ADDED
-3Fh/13h  DZTPR MAEBK F  iSCSI IP ADDRESS REMOVED
-3Fh/14h  DZTPR MAEBK F  iSCSI IP ADDRESS CHANGED
-3Fh/15h  DZTPR MAEBK    INSPECT REFERRALS SENSE DESCRIPTORS
-3Fh/16h  DZTPROMAEBKVF  MICROCODE HAS BEEN CHANGED WITHOUT RESET
-3Fh/17h  DZ             ZONE TRANSITION TO FULL
-3Fh/18h  D              BIND COMPLETED
-3Fh/19h  D              BIND REDIRECTED
-3Fh/1Ah  D              SUBSIDIARY BINDING CHANGED
-40h/00h  DZ             RAM FAILURE (SHOULD USE 40 NN)
-40h/NNh  DZTPROMAEBKVF  DIAGNOSTIC FAILURE ON COMPONENT NN (80h-FFh)
-41h/00h  DZ             DATA PATH FAILURE (SHOULD USE 40 NN)
-42h/00h  DZ             POWER-ON OR SELF-TEST FAILURE (SHOULD USE 40 NN)
-43h/00h  DZTPROMAEBKVF  MESSAGE ERROR
-44h/00h  DZTPROMAEBKVF  INTERNAL TARGET FAILURE
-44h/01h  DZTP  MAEBKVF  PERSISTENT RESERVATION INFORMATION LOST
-44h/71h  DZT      B     ATA DEVICE FAILED SET FEATURES
-45h/00h  DZTPROMAEBKVF  SELECT OR RESELECT FAILURE
-46h/00h  DZTPROM  BK    UNSUCCESSFUL SOFT RESET
-47h/00h  DZTPROMAEBKVF  SCSI PARITY ERROR
-47h/01h  DZTPROMAEBKVF  DATA PHASE CRC ERROR DETECTED
-47h/02h  DZTPROMAEBKVF  SCSI PARITY ERROR DETECTED DURING ST DATA PHASE
-47h/03h  DZTPROMAEBKVF  INFORMATION UNIT iuCRC ERROR DETECTED
-47h/04h  DZTPROMAEBKVF  ASYNCHRONOUS INFORMATION PROTECTION ERROR DETECTED
-47h/05h  DZTPROMAEBKVF  PROTOCOL SERVICE CRC ERROR
-47h/06h  DZT   MAEBKVF  PHY TEST FUNCTION IN PROGRESS
-47h/7Fh  DZTPROMAEBK    SOME COMMANDS CLEARED BY ISCSI PROTOCOL EVENT
-48h/00h  DZTPROMAEBKVF  INITIATOR DETECTED ERROR MESSAGE RECEIVED
-49h/00h  DZTPROMAEBKVF  INVALID MESSAGE ERROR
-4Ah/00h  DZTPROMAEBKVF  COMMAND PHASE ERROR
-4Bh/00h  DZTPROMAEBKVF  DATA PHASE ERROR
-4Bh/01h  DZTPROMAEBK    INVALID TARGET PORT TRANSFER TAG RECEIVED
-4Bh/02h  DZTPROMAEBK    TOO MUCH WRITE DATA
-4Bh/03h  DZTPROMAEBK    ACK/NAK TIMEOUT
-4Bh/04h  DZTPROMAEBK    NAK RECEIVED
-4Bh/05h  DZTPROMAEBK    DATA OFFSET ERROR
-4Bh/06h  DZTPROMAEBK    INITIATOR RESPONSE TIMEOUT
-4Bh/07h  DZTPROMAEBK F  CONNECTION LOST
-4Bh/08h  DZTPROMAEBK F  DATA-IN BUFFER OVERFLOW - DATA BUFFER SIZE
-4Bh/09h  DZTPROMAEBK F  DATA-IN BUFFER OVERFLOW - DATA BUFFER DESCRIPTOR AREA
-4Bh/0Ah  DZTPROMAEBK F  DATA-IN BUFFER ERROR
-4Bh/0Bh  DZTPROMAEBK F  DATA-OUT BUFFER OVERFLOW - DATA BUFFER SIZE
-4Bh/0Ch  DZTPROMAEBK F  DATA-OUT BUFFER OVERFLOW - DATA BUFFER DESCRIPTOR AREA
-4Bh/0Dh  DZTPROMAEBK F  DATA-OUT BUFFER ERROR
-4Bh/0Eh  DZTPROMAEBK F  PCIE FABRIC ERROR
-4Bh/0Fh  DZTPROMAEBK F  PCIE COMPLETION TIMEOUT
-4Bh/10h  DZTPROMAEBK F  PCIE COMPLETER ABORT
-4Bh/11h  DZTPROMAEBK F  PCIE POISONED TLP RECEIVED
-4Bh/12h  DZTPROMAEBK F  PCIE ECRC CHECK FAILED
-4Bh/13h  DZTPROMAEBK F  PCIE UNSUPPORTED REQUEST
-4Bh/14h  DZTPROMAEBK F  PCIE ACS VIOLATION
-4Bh/15h  DZTPROMAEBK F  PCIE TLP PREFIX BLOCKED
-4Ch/00h  DZTPROMAEBKVF  LOGICAL UNIT FAILED SELF-CONFIGURATION
-4Dh/NNh  DZTPROMAEBKVF  TAGGED OVERLAPPED COMMANDS (NN = TASK TAG)
-4Eh/00h  DZTPROMAEBKVF  OVERLAPPED COMMANDS ATTEMPTED
-4Fh/00h
-50h/00h    T            WRITE APPEND ERROR
-50h/01h    T            WRITE APPEND POSITION ERROR
-50h/02h    T            POSITION ERROR RELATED TO TIMING
-51h/00h  DZT RO         ERASE FAILURE
-51h/01h  DZ  R          ERASE FAILURE - INCOMPLETE ERASE OPERATION DETECTED
-52h/00h    T            CARTRIDGE FAULT
-53h/00h  DZT ROM  BK    MEDIA LOAD OR EJECT FAILED
-53h/01h    T            UNLOAD TAPE FAILURE
-53h/02h  DZT ROM  BK    MEDIUM REMOVAL PREVENTED
-53h/03h        M        MEDIUM REMOVAL PREVENTED BY DATA TRANSFER ELEMENT
-53h/04h    T            MEDIUM THREAD OR UNTHREAD FAILURE
-53h/05h        M        VOLUME IDENTIFIER INVALID
-53h/06h        M        VOLUME IDENTIFIER MISSING
-53h/07h        M        DUPLICATE VOLUME IDENTIFIER
-53h/08h        M        ELEMENT STATUS UNKNOWN
-53h/09h        M        DATA TRANSFER DEVICE ERROR - LOAD FAILED
-53h/0Ah        M        DATA TRANSFER DEVICE ERROR - UNLOAD FAILED
-53h/0Bh        M        DATA TRANSFER DEVICE ERROR - UNLOAD MISSING
-53h/0Ch        M        DATA TRANSFER DEVICE ERROR - EJECT FAILED
-53h/0Dh        M        DATA TRANSFER DEVICE ERROR - LIBRARY COMMUNICATION FAILED
-54h/00h     P           SCSI TO HOST SYSTEM INTERFACE FAILURE
-55h/00h     P           SYSTEM RESOURCE FAILURE
-55h/01h  DZ   O   BK    SYSTEM BUFFER FULL
-55h/02h  DZTPROMAE K    INSUFFICIENT RESERVATION RESOURCES
-55h/03h  DZTPROMAE K    INSUFFICIENT RESOURCES
-55h/04h  DZTPROMAE K    INSUFFICIENT REGISTRATION RESOURCES
-55h/05h  DZTPROMAEBK    INSUFFICIENT ACCESS CONTROL RESOURCES
-55h/06h  DZT ROM  B     AUXILIARY MEMORY OUT OF SPACE
-55h/07h              F  QUOTA ERROR
-55h/08h    T            MAXIMUM NUMBER OF SUPPLEMENTAL DECRYPTION KEYS EXCEEDED
-55h/09h        M        MEDIUM AUXILIARY MEMORY NOT ACCESSIBLE
-55h/0Ah  DZ    M        DATA CURRENTLY UNAVAILABLE
-55h/0Bh  DZTPROMAEBKVF  INSUFFICIENT POWER FOR OPERATION
-55h/0Ch  DZTP     B     INSUFFICIENT RESOURCES TO CREATE ROD
-55h/0Dh  DZTP     B     INSUFFICIENT RESOURCES TO CREATE ROD TOKEN
-55h/0Eh  DZ             INSUFFICIENT ZONE RESOURCES
-55h/0Fh  DZ             INSUFFICIENT ZONE RESOURCES TO COMPLETE WRITE
-55h/10h  DZ             MAXIMUM NUMBER OF STREAMS OPEN
-55h/11h  D              INSUFFICIENT RESOURCES TO BIND
-56h/00h
-57h/00h      R          UNABLE TO RECOVER TABLE-OF-CONTENTS
-58h/00h       O         GENERATION DOES NOT EXIST
-59h/00h       O         UPDATED BLOCK READ
-5Ah/00h  DZTPRO   BK    OPERATOR REQUEST OR STATE CHANGE INPUT
-5Ah/01h  DZT ROM  BK    OPERATOR MEDIUM REMOVAL REQUEST
-5Ah/02h  DZT RO A BK    OPERATOR SELECTED WRITE PROTECT
-5Ah/03h  DZT RO A BK    OPERATOR SELECTED WRITE PERMIT
-5Bh/00h  DZTPROM   K    LOG EXCEPTION
-5Bh/01h  DZTPROM   K    THRESHOLD CONDITION MET
-5Bh/02h  DZTPROM   K    LOG COUNTER AT MAXIMUM
-5Bh/03h  DZTPROM   K    LOG LIST CODES EXHAUSTED
-5Ch/00h  DZ   O         RPL STATUS CHANGE
-5Ch/01h  DZ   O         SPINDLES SYNCHRONIZED
-5Ch/02h  DZ   O         SPINDLES NOT SYNCHRONIZED
-5Dh/00h  DZTPROMAEBKVF  FAILURE PREDICTION THRESHOLD EXCEEDED
-5Dh/01h      R    B     MEDIA FAILURE PREDICTION THRESHOLD EXCEEDED
-5Dh/02h      R          LOGICAL UNIT FAILURE PREDICTION THRESHOLD EXCEEDED
-5Dh/03h      R          SPARE AREA EXHAUSTION PREDICTION THRESHOLD EXCEEDED
-5Dh/10h  DZ       B     HARDWARE IMPENDING FAILURE GENERAL HARD DRIVE FAILURE
-5Dh/11h  DZ       B     HARDWARE IMPENDING FAILURE DRIVE ERROR RATE TOO HIGH
-5Dh/12h  DZ       B     HARDWARE IMPENDING FAILURE DATA ERROR RATE TOO HIGH
-5Dh/13h  DZ       B     HARDWARE IMPENDING FAILURE SEEK ERROR RATE TOO HIGH
-5Dh/14h  DZ       B     HARDWARE IMPENDING FAILURE TOO MANY BLOCK REASSIGNS
-5Dh/15h  DZ       B     HARDWARE IMPENDING FAILURE ACCESS TIMES TOO HIGH
-5Dh/16h  DZ       B     HARDWARE IMPENDING FAILURE START UNIT TIMES TOO HIGH
-5Dh/17h  DZ       B     HARDWARE IMPENDING FAILURE CHANNEL PARAMETRICS
-5Dh/18h  DZ       B     HARDWARE IMPENDING FAILURE CONTROLLER DETECTED
-5Dh/19h  DZ       B     HARDWARE IMPENDING FAILURE THROUGHPUT PERFORMANCE
-5Dh/1Ah  DZ       B     HARDWARE IMPENDING FAILURE SEEK TIME PERFORMANCE
-5Dh/1Bh  DZ       B     HARDWARE IMPENDING FAILURE SPIN-UP RETRY COUNT
-5Dh/1Ch  DZ       B     HARDWARE IMPENDING FAILURE DRIVE CALIBRATION RETRY COUNT
-5Dh/1Dh  DZ       B     HARDWARE IMPENDING FAILURE POWER LOSS PROTECTION CIRCUIT
-5Dh/20h  DZ       B     CONTROLLER IMPENDING FAILURE GENERAL HARD DRIVE FAILURE
-5Dh/21h  DZ       B     CONTROLLER IMPENDING FAILURE DRIVE ERROR RATE TOO HIGH
-5Dh/22h  DZ       B     CONTROLLER IMPENDING FAILURE DATA ERROR RATE TOO HIGH
-5Dh/23h  DZ       B     CONTROLLER IMPENDING FAILURE SEEK ERROR RATE TOO HIGH
-5Dh/24h  DZ       B     CONTROLLER IMPENDING FAILURE TOO MANY BLOCK REASSIGNS
-5Dh/25h  DZ       B     CONTROLLER IMPENDING FAILURE ACCESS TIMES TOO HIGH
-5Dh/26h  DZ       B     CONTROLLER IMPENDING FAILURE START UNIT TIMES TOO HIGH
-5Dh/27h  DZ       B     CONTROLLER IMPENDING FAILURE CHANNEL PARAMETRICS
-5Dh/28h  DZ       B     CONTROLLER IMPENDING FAILURE CONTROLLER DETECTED
-5Dh/29h  DZ       B     CONTROLLER IMPENDING FAILURE THROUGHPUT PERFORMANCE
-5Dh/2Ah  DZ       B     CONTROLLER IMPENDING FAILURE SEEK TIME PERFORMANCE
-5Dh/2Bh  DZ       B     CONTROLLER IMPENDING FAILURE SPIN-UP RETRY COUNT
-5Dh/2Ch  DZ       B     CONTROLLER IMPENDING FAILURE DRIVE CALIBRATION RETRY COUNT
-5Dh/30h  DZ       B     DATA CHANNEL IMPENDING FAILURE GENERAL HARD DRIVE FAILURE
-5Dh/31h  DZ       B     DATA CHANNEL IMPENDING FAILURE DRIVE ERROR RATE TOO HIGH
-5Dh/32h  DZ       B     DATA CHANNEL IMPENDING FAILURE DATA ERROR RATE TOO HIGH
-5Dh/33h  DZ       B     DATA CHANNEL IMPENDING FAILURE SEEK ERROR RATE TOO HIGH
-5Dh/34h  DZ       B     DATA CHANNEL IMPENDING FAILURE TOO MANY BLOCK REASSIGNS
-5Dh/35h  DZ       B     DATA CHANNEL IMPENDING FAILURE ACCESS TIMES TOO HIGH
-5Dh/36h  DZ       B     DATA CHANNEL IMPENDING FAILURE START UNIT TIMES TOO HIGH
-5Dh/37h  DZ       B     DATA CHANNEL IMPENDING FAILURE CHANNEL PARAMETRICS
-5Dh/38h  DZ       B     DATA CHANNEL IMPENDING FAILURE CONTROLLER DETECTED
-5Dh/39h  DZ       B     DATA CHANNEL IMPENDING FAILURE THROUGHPUT PERFORMANCE
-5Dh/3Ah  DZ       B     DATA CHANNEL IMPENDING FAILURE SEEK TIME PERFORMANCE
-5Dh/3Bh  DZ       B     DATA CHANNEL IMPENDING FAILURE SPIN-UP RETRY COUNT
-5Dh/3Ch  DZ       B     DATA CHANNEL IMPENDING FAILURE DRIVE CALIBRATION RETRY COUNT
-5Dh/40h  DZ       B     SERVO IMPENDING FAILURE GENERAL HARD DRIVE FAILURE
-5Dh/41h  DZ       B     SERVO IMPENDING FAILURE DRIVE ERROR RATE TOO HIGH
-5Dh/42h  DZ       B     SERVO IMPENDING FAILURE DATA ERROR RATE TOO HIGH
-5Dh/43h  DZ       B     SERVO IMPENDING FAILURE SEEK ERROR RATE TOO HIGH
-5Dh/44h  DZ       B     SERVO IMPENDING FAILURE TOO MANY BLOCK REASSIGNS
-5Dh/45h  DZ       B     SERVO IMPENDING FAILURE ACCESS TIMES TOO HIGH
-5Dh/46h  DZ       B     SERVO IMPENDING FAILURE START UNIT TIMES TOO HIGH
-5Dh/47h  DZ       B     SERVO IMPENDING FAILURE CHANNEL PARAMETRICS
-5Dh/48h  DZ       B     SERVO IMPENDING FAILURE CONTROLLER DETECTED
-5Dh/49h  DZ       B     SERVO IMPENDING FAILURE THROUGHPUT PERFORMANCE
-5Dh/4Ah  DZ       B     SERVO IMPENDING FAILURE SEEK TIME PERFORMANCE
-5Dh/4Bh  DZ       B     SERVO IMPENDING FAILURE SPIN-UP RETRY COUNT
-5Dh/4Ch  DZ       B     SERVO IMPENDING FAILURE DRIVE CALIBRATION RETRY COUNT
-5Dh/50h  DZ       B     SPINDLE IMPENDING FAILURE GENERAL HARD DRIVE FAILURE
-5Dh/51h  DZ       B     SPINDLE IMPENDING FAILURE DRIVE ERROR RATE TOO HIGH
-5Dh/52h  DZ       B     SPINDLE IMPENDING FAILURE DATA ERROR RATE TOO HIGH
-5Dh/53h  DZ       B     SPINDLE IMPENDING FAILURE SEEK ERROR RATE TOO HIGH
-5Dh/54h  DZ       B     SPINDLE IMPENDING FAILURE TOO MANY BLOCK REASSIGNS
-5Dh/55h  DZ       B     SPINDLE IMPENDING FAILURE ACCESS TIMES TOO HIGH
-5Dh/56h  DZ       B     SPINDLE IMPENDING FAILURE START UNIT TIMES TOO HIGH
-5Dh/57h  DZ       B     SPINDLE IMPENDING FAILURE CHANNEL PARAMETRICS
-5Dh/58h  DZ       B     SPINDLE IMPENDING FAILURE CONTROLLER DETECTED
-5Dh/59h  DZ       B     SPINDLE IMPENDING FAILURE THROUGHPUT PERFORMANCE
-5Dh/5Ah  DZ       B     SPINDLE IMPENDING FAILURE SEEK TIME PERFORMANCE
-5Dh/5Bh  DZ       B     SPINDLE IMPENDING FAILURE SPIN-UP RETRY COUNT
-5Dh/5Ch  DZ       B     SPINDLE IMPENDING FAILURE DRIVE CALIBRATION RETRY COUNT
-5Dh/60h  DZ       B     FIRMWARE IMPENDING FAILURE GENERAL HARD DRIVE FAILURE
-5Dh/61h  DZ       B     FIRMWARE IMPENDING FAILURE DRIVE ERROR RATE TOO HIGH
-5Dh/62h  DZ       B     FIRMWARE IMPENDING FAILURE DATA ERROR RATE TOO HIGH
-5Dh/63h  DZ       B     FIRMWARE IMPENDING FAILURE SEEK ERROR RATE TOO HIGH
-5Dh/64h  DZ       B     FIRMWARE IMPENDING FAILURE TOO MANY BLOCK REASSIGNS
-5Dh/65h  DZ       B     FIRMWARE IMPENDING FAILURE ACCESS TIMES TOO HIGH
-5Dh/66h  DZ       B     FIRMWARE IMPENDING FAILURE START UNIT TIMES TOO HIGH
-5Dh/67h  DZ       B     FIRMWARE IMPENDING FAILURE CHANNEL PARAMETRICS
-5Dh/68h  DZ       B     FIRMWARE IMPENDING FAILURE CONTROLLER DETECTED
-5Dh/69h  DZ       B     FIRMWARE IMPENDING FAILURE THROUGHPUT PERFORMANCE
-5Dh/6Ah  DZ       B     FIRMWARE IMPENDING FAILURE SEEK TIME PERFORMANCE
-5Dh/6Bh  DZ       B     FIRMWARE IMPENDING FAILURE SPIN-UP RETRY COUNT
-5Dh/6Ch  DZ       B     FIRMWARE IMPENDING FAILURE DRIVE CALIBRATION RETRY COUNT
-5Dh/73h  DZ       B     MEDIA IMPENDING FAILURE ENDURANCE LIMIT MET
-5Dh/FFh  DZTPROMAEBKVF  FAILURE PREDICTION THRESHOLD EXCEEDED (FALSE)
-5Eh/00h  DZTPRO A  K    LOW POWER CONDITION ON
-5Eh/01h  DZTPRO A  K    IDLE CONDITION ACTIVATED BY TIMER
-5Eh/02h  DZTPRO A  K    STANDBY CONDITION ACTIVATED BY TIMER
-5Eh/03h  DZTPRO A  K    IDLE CONDITION ACTIVATED BY COMMAND
-5Eh/04h  DZTPRO A  K    STANDBY CONDITION ACTIVATED BY COMMAND
-5Eh/05h  DZTPRO A  K    IDLE_B CONDITION ACTIVATED BY TIMER
-5Eh/06h  DZTPRO A  K    IDLE_B CONDITION ACTIVATED BY COMMAND
-5Eh/07h  DZTPRO A  K    IDLE_C CONDITION ACTIVATED BY TIMER
-5Eh/08h  DZTPRO A  K    IDLE_C CONDITION ACTIVATED BY COMMAND
-5Eh/09h  DZTPRO A  K    STANDBY_Y CONDITION ACTIVATED BY TIMER
-5Eh/0Ah  DZTPRO A  K    STANDBY_Y CONDITION ACTIVATED BY COMMAND
-5Eh/41h           B     POWER STATE CHANGE TO ACTIVE
-5Eh/42h           B     POWER STATE CHANGE TO IDLE
-5Eh/43h           B     POWER STATE CHANGE TO STANDBY
-5Eh/45h           B     POWER STATE CHANGE TO SLEEP
-5Eh/47h           BK    POWER STATE CHANGE TO DEVICE CONTROL
-5Fh/00h
-60h/00h                 LAMP FAILURE
-61h/00h                 VIDEO ACQUISITION ERROR
-61h/01h                 UNABLE TO ACQUIRE VIDEO
-61h/02h                 OUT OF FOCUS
-62h/00h                 SCAN HEAD POSITIONING ERROR
-63h/00h      R          END OF USER AREA ENCOUNTERED ON THIS TRACK
-63h/01h      R          PACKET DOES NOT FIT IN AVAILABLE SPACE
-64h/00h      R          ILLEGAL MODE FOR THIS TRACK
-64h/01h      R          INVALID PACKET SIZE
-65h/00h  DZTPROMAEBKVF  VOLTAGE FAULT
-66h/00h                 AUTOMATIC DOCUMENT FEEDER COVER UP
-66h/01h                 AUTOMATIC DOCUMENT FEEDER LIFT UP
-66h/02h                 DOCUMENT JAM IN AUTOMATIC DOCUMENT FEEDER
-66h/03h                 DOCUMENT MISS FEED AUTOMATIC IN DOCUMENT FEEDER
-67h/00h         A       CONFIGURATION FAILURE
-67h/01h         A       CONFIGURATION OF INCAPABLE LOGICAL UNITS FAILED
-67h/02h         A       ADD LOGICAL UNIT FAILED
-67h/03h         A       MODIFICATION OF LOGICAL UNIT FAILED
-67h/04h         A       EXCHANGE OF LOGICAL UNIT FAILED
-67h/05h         A       REMOVE OF LOGICAL UNIT FAILED
-67h/06h         A       ATTACHMENT OF LOGICAL UNIT FAILED
-67h/07h         A       CREATION OF LOGICAL UNIT FAILED
-67h/08h         A       ASSIGN FAILURE OCCURRED
-67h/09h         A       MULTIPLY ASSIGNED LOGICAL UNIT
-67h/0Ah  DZTPROMAEBKVF  SET TARGET PORT GROUPS COMMAND FAILED
-67h/0Bh  DZT      B     ATA DEVICE FEATURE NOT ENABLED
-67h/0Ch  D              COMMAND REJECTED
-67h/0Dh  D              EXPLICIT BIND NOT ALLOWED
-68h/00h         A       LOGICAL UNIT NOT CONFIGURED
-68h/01h  DZ             SUBSIDIARY LOGICAL UNIT NOT CONFIGURED
-69h/00h         A       DATA LOSS ON LOGICAL UNIT
-69h/01h         A       MULTIPLE LOGICAL UNIT FAILURES
-69h/02h         A       PARITY/DATA MISMATCH
-6Ah/00h         A       INFORMATIONAL, REFER TO LOG
-6Bh/00h         A       STATE CHANGE HAS OCCURRED
-6Bh/01h         A       REDUNDANCY LEVEL GOT BETTER
-6Bh/02h         A       REDUNDANCY LEVEL GOT WORSE
-6Ch/00h         A       REBUILD FAILURE OCCURRED
-6Dh/00h         A       RECALCULATE FAILURE OCCURRED
-6Eh/00h         A       COMMAND TO LOGICAL UNIT FAILED
-6Fh/00h      R          COPY PROTECTION KEY EXCHANGE FAILURE - AUTHENTICATION FAILURE
-6Fh/01h      R          COPY PROTECTION KEY EXCHANGE FAILURE - KEY NOT PRESENT
-6Fh/02h      R          COPY PROTECTION KEY EXCHANGE FAILURE - KEY NOT ESTABLISHED
-6Fh/03h      R          READ OF SCRAMBLED SECTOR WITHOUT AUTHENTICATION
-6Fh/04h      R          MEDIA REGION CODE IS MISMATCHED TO LOGICAL UNIT REGION
-6Fh/05h      R          DRIVE REGION MUST BE PERMANENT/REGION RESET COUNT ERROR
-6Fh/06h      R          INSUFFICIENT BLOCK COUNT FOR BINDING NONCE RECORDING
-6Fh/07h      R          CONFLICT IN BINDING NONCE RECORDING
-6Fh/08h      R          INSUFFICIENT PERMISSION
-6Fh/09h      R          INVALID DRIVE-HOST PAIRING SERVER
-6Fh/0Ah      R          DRIVE-HOST PAIRING SUSPENDED
-70h/NNh    T            DECOMPRESSION EXCEPTION SHORT ALGORITHM ID OF NN
-71h/00h    T            DECOMPRESSION EXCEPTION LONG ALGORITHM ID
-72h/00h      R          SESSION FIXATION ERROR
-72h/01h      R          SESSION FIXATION ERROR WRITING LEAD-IN
-72h/02h      R          SESSION FIXATION ERROR WRITING LEAD-OUT
-72h/03h      R          SESSION FIXATION ERROR - INCOMPLETE TRACK IN SESSION
-72h/04h      R          EMPTY OR PARTIALLY WRITTEN RESERVED TRACK
-72h/05h      R          NO MORE TRACK RESERVATIONS ALLOWED
-72h/06h      R          RMZ EXTENSION IS NOT ALLOWED
-72h/07h      R          NO MORE TEST ZONE EXTENSIONS ARE ALLOWED
-73h/00h      R          CD CONTROL ERROR
-73h/01h      R          POWER CALIBRATION AREA ALMOST FULL
-73h/02h      R          POWER CALIBRATION AREA IS FULL
-73h/03h      R          POWER CALIBRATION AREA ERROR
-73h/04h      R          PROGRAM MEMORY AREA UPDATE FAILURE
-73h/05h      R          PROGRAM MEMORY AREA IS FULL
-73h/06h      R          RMA/PMA IS ALMOST FULL
-73h/10h      R          CURRENT POWER CALIBRATION AREA ALMOST FULL
-73h/11h      R          CURRENT POWER CALIBRATION AREA IS FULL
-73h/17h      R          RDZ IS FULL
-74h/00h    T            SECURITY ERROR
-74h/01h    T            UNABLE TO DECRYPT DATA
-74h/02h    T            UNENCRYPTED DATA ENCOUNTERED WHILE DECRYPTING
-74h/03h    T            INCORRECT DATA ENCRYPTION KEY
-74h/04h    T            CRYPTOGRAPHIC INTEGRITY VALIDATION FAILED
-74h/05h    T            ERROR DECRYPTING DATA
-74h/06h    T            UNKNOWN SIGNATURE VERIFICATION KEY
-74h/07h    T            ENCRYPTION PARAMETERS NOT USEABLE
-74h/08h  DZT R M E  VF  DIGITAL SIGNATURE VALIDATION FAILURE
-74h/09h    T            ENCRYPTION MODE MISMATCH ON READ
-74h/0Ah    T            ENCRYPTED BLOCK NOT RAW READ ENABLED
-74h/0Bh    T            INCORRECT ENCRYPTION PARAMETERS
-74h/0Ch  DZT R MAEBKV   UNABLE TO DECRYPT PARAMETER LIST
-74h/0Dh    T            ENCRYPTION ALGORITHM DISABLED
-74h/10h  DZT R MAEBKV   SA CREATION PARAMETER VALUE INVALID
-74h/11h  DZT R MAEBKV   SA CREATION PARAMETER VALUE REJECTED
-74h/12h  DZT R MAEBKV   INVALID SA USAGE
-74h/21h    T            DATA ENCRYPTION CONFIGURATION PREVENTED
-74h/30h  DZT R MAEBKV   SA CREATION PARAMETER NOT SUPPORTED
-74h/40h  DZT R MAEBKV   AUTHENTICATION FAILED
-74h/61h             V   EXTERNAL DATA ENCRYPTION KEY MANAGER ACCESS ERROR
-74h/62h             V   EXTERNAL DATA ENCRYPTION KEY MANAGER ERROR
-74h/63h             V   EXTERNAL DATA ENCRYPTION KEY NOT FOUND
-74h/64h             V   EXTERNAL DATA ENCRYPTION REQUEST NOT AUTHORIZED
-74h/6Eh    T            EXTERNAL DATA ENCRYPTION CONTROL TIMEOUT
-74h/6Fh    T            EXTERNAL DATA ENCRYPTION CONTROL ERROR
-74h/71h  DZT R M E  V   LOGICAL UNIT ACCESS NOT AUTHORIZED
-74h/79h  DZ             SECURITY CONFLICT IN TRANSLATED DEVICE
-75h/00h
-76h/00h
-77h/00h
-78h/00h
-79h/00h
-7Ah/00h
-7Bh/00h
-7Ch/00h
-7Dh/00h
-7Eh/00h
-7Fh/00h
-*/
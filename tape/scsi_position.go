@@ -0,0 +1,194 @@
+package tape
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// Position is a logical position on the medium.
+// Block is the logical object number, counting filemarks as the drive does.
+// File and Set are 0 when the drive does not report them.
+type Position struct {
+	Partition uint32
+	Block     uint64
+	File      uint64
+	Set       uint64
+	BOP       bool // at beginning of partition
+	EOP       bool // between early warning and end of partition
+	BPEW      bool // beyond programmable early warning
+}
+
+func (p Position) String() string {
+	s := fmt.Sprintf("part=%d block=%d file=%d", p.Partition, p.Block, p.File)
+	if p.BOP {
+		s += " BOP"
+	}
+	if p.EOP {
+		s += " EOP"
+	}
+	if p.BPEW {
+		s += " BPEW"
+	}
+	return s
+}
+
+// Service actions of READ POSITION
+const (
+	PositionShort    = 0x00
+	PositionLong     = 0x06
+	PositionExtended = 0x08
+)
+
+var ErrPositionUnknown = errors.New("read position: position unknown")
+
+func parsePosition(form byte, dat []byte) (Position, error) {
+	var pos Position
+	switch form {
+	case PositionShort:
+		if len(dat) < 20 {
+			return pos, fmt.Errorf("read position: short data %d", len(dat))
+		}
+		if dat[0]&0x04 != 0 { // BPU
+			return pos, ErrPositionUnknown
+		}
+		pos.Partition = uint32(dat[1])
+		pos.Block = uint64(binary.BigEndian.Uint32(dat[4:]))
+	case PositionLong:
+		if len(dat) < 32 {
+			return pos, fmt.Errorf("read position: short data %d", len(dat))
+		}
+		if dat[0]&0x04 != 0 { // LONU
+			return pos, ErrPositionUnknown
+		}
+		pos.Partition = binary.BigEndian.Uint32(dat[4:])
+		pos.Block = binary.BigEndian.Uint64(dat[8:])
+		if dat[0]&0x08 == 0 { // MPU
+			pos.File = binary.BigEndian.Uint64(dat[16:])
+			pos.Set = binary.BigEndian.Uint64(dat[24:])
+		}
+	case PositionExtended:
+		if len(dat) < 32 {
+			return pos, fmt.Errorf("read position: short data %d", len(dat))
+		}
+		if dat[0]&0x04 != 0 { // LOLU
+			return pos, ErrPositionUnknown
+		}
+		pos.Partition = uint32(dat[1])
+		pos.Block = binary.BigEndian.Uint64(dat[8:])
+	default:
+		return pos, fmt.Errorf("read position: unknown form %#02x", form)
+	}
+	pos.BOP = dat[0]&0x80 != 0
+	pos.EOP = dat[0]&0x40 != 0
+	pos.BPEW = dat[0]&0x01 != 0
+	return pos, nil
+}
+
+// ReadPositionForm issues READ POSITION with service action form, bypassing the cache
+func (d Drive) ReadPositionForm(form byte) (Position, error) {
+	dat, err := d.scsiRead([]byte{
+		ScsiOpReadPosition, form & 0x1f,
+		0, 0, 0, 0, 0, // reserved
+		0, 0, // must be 0
+		0,
+	}, 32, 60_000)
+	if err != nil {
+		return Position{}, err
+	}
+	return parsePosition(form, dat)
+}
+
+// Tell returns the current position, from cache if nothing moved the medium since last asked
+func (d Drive) Tell() (Position, error) {
+	if p := d.state.pos; p != nil {
+		return *p, nil
+	}
+	if !d.direct {
+		// st keeps written data in its buffer until flushed
+		if err := d.MTNOP(); err != nil {
+			return Position{}, err
+		}
+	}
+	form := byte(PositionShort)
+	switch caps := d.caps(); {
+	case caps.Supports(ScsiOpReadPosition, PositionLong):
+		form = PositionLong
+	case caps.Supports(ScsiOpReadPosition, PositionExtended):
+		form = PositionExtended
+	}
+	pos, err := d.ReadPositionForm(form)
+	if err != nil {
+		return Position{}, err
+	}
+	d.state.pos = &pos
+	return pos, nil
+}
+
+func (d Drive) ReadPosition() (Position, error) { return d.Tell() }
+
+// Seek locates pos.Block of pos.Partition, doing nothing if already there
+func (d Drive) Seek(ctx context.Context, pos Position) error {
+	if p := d.state.pos; p != nil && p.Partition == pos.Partition && p.Block == pos.Block {
+		return nil
+	}
+	return d.LocateContext(ctx, byte(pos.Partition), pos.Block, nil)
+}
+
+// SeekFile locates the beginning of file of part, right after filemark file-1
+func (d Drive) SeekFile(ctx context.Context, part uint32, file uint64) error {
+	if d.caps().Supports(ScsiOpLocate16, 0) {
+		return d.locate16Immed(ctx, Locate16FlagDestFileID, byte(part), file)
+	}
+	err := d.LocateContext(ctx, byte(part), 0, nil)
+	if err != nil || file == 0 {
+		return err
+	}
+	return d.Space(SpaceFilemarks, int32(file))
+}
+
+// SeekEOD locates the end of data of part, where appending continues
+func (d Drive) SeekEOD(ctx context.Context, part uint32) error {
+	if d.caps().Supports(ScsiOpLocate16, 0) {
+		return d.locate16Immed(ctx, Locate16FlagDestEOD, byte(part), 0)
+	}
+	err := d.LocateContext(ctx, byte(part), 0, nil)
+	if err != nil {
+		return err
+	}
+	return d.Space(SpaceEOD, 0)
+}
+
+func (d Drive) locate16Immed(ctx context.Context, dest byte, part byte, id uint64) error {
+	return d.immed(ctx, []byte{
+		ScsiOpLocate16, dest | Locate16FlagWithPart | 0x01, 0, part,
+		byte(id >> 56), byte(id >> 48), byte(id >> 40), byte(id >> 32),
+		byte(id >> 24), byte(id >> 16), byte(id >> 8), byte(id),
+		0, 0, 0, 0,
+	}, nil)
+}
+
+// movesMedium reports whether op changes the position, dropping the cached one
+func movesMedium(op byte) bool {
+	switch op {
+	case ScsiOpRead, ScsiOpWrite, ScsiOpWriteFilemarks, ScsiOpSpace6, ScsiOpSpace16,
+		ScsiOpLocate10, ScsiOpLocate16, ScsiOpRewind, ScsiOpErase, ScsiOpLoadUnload,
+		ScsiOpFormatMedium, ScsiOpVerify:
+		return true
+	}
+	return false
+}
+
+// advance moves the cached position from prev over blocks and filemarks
+func (d Drive) advance(prev *Position, blocks, filemarks uint64, bpew bool) {
+	if prev == nil {
+		return
+	}
+	p := *prev
+	p.Block += blocks + filemarks
+	p.File += filemarks
+	p.BOP = false
+	p.BPEW = p.BPEW || bpew
+	d.state.pos = &p
+}
@@ -0,0 +1,127 @@
+package tape
+
+import (
+	"encoding/binary"
+	"errors"
+	"testing"
+)
+
+// positionShort builds short form READ POSITION data
+func positionShort(flags byte, part byte, block uint32) []byte {
+	b := make([]byte, 20)
+	b[0], b[1] = flags, part
+	binary.BigEndian.PutUint32(b[4:], block)
+	binary.BigEndian.PutUint32(b[8:], block)
+	return b
+}
+
+func positionLong(flags byte, part uint32, block, file, set uint64) []byte {
+	b := make([]byte, 32)
+	b[0] = flags
+	binary.BigEndian.PutUint32(b[4:], part)
+	binary.BigEndian.PutUint64(b[8:], block)
+	binary.BigEndian.PutUint64(b[16:], file)
+	binary.BigEndian.PutUint64(b[24:], set)
+	return b
+}
+
+func positionExtended(flags byte, part byte, block uint64) []byte {
+	b := make([]byte, 32)
+	b[0], b[1] = flags, part
+	binary.BigEndian.PutUint16(b[2:], 0x1c)
+	binary.BigEndian.PutUint64(b[8:], block)
+	binary.BigEndian.PutUint64(b[16:], block)
+	return b
+}
+
+func TestParsePosition(t *testing.T) {
+	tests := []struct {
+		name string
+		form byte
+		dat  []byte
+		want Position
+		err  error
+	}{
+		{"short BOP", PositionShort, positionShort(0x80, 0, 0), Position{BOP: true}, nil},
+		{"short", PositionShort, positionShort(0x00, 1, 1234), Position{Partition: 1, Block: 1234}, nil},
+		{"short EOP BPEW", PositionShort, positionShort(0x41, 0, 0xfffffffe),
+			Position{Block: 0xfffffffe, EOP: true, BPEW: true}, nil},
+		{"short BPU", PositionShort, positionShort(0x04, 0, 0), Position{}, ErrPositionUnknown},
+		{"short truncated", PositionShort, positionShort(0, 0, 0)[:16], Position{}, errAny},
+
+		{"long", PositionLong, positionLong(0x00, 1, 1<<33, 7, 1), Position{Partition: 1, Block: 1 << 33, File: 7, Set: 1}, nil},
+		{"long BOP", PositionLong, positionLong(0x80, 0, 0, 0, 0), Position{BOP: true}, nil},
+		{"long MPU", PositionLong, positionLong(0x08|0x40, 3, 99, 7, 1), Position{Partition: 3, Block: 99, EOP: true}, nil},
+		{"long LONU", PositionLong, positionLong(0x04, 0, 0, 0, 0), Position{}, ErrPositionUnknown},
+		{"long truncated", PositionLong, positionLong(0, 0, 0, 0, 0)[:20], Position{}, errAny},
+
+		{"extended", PositionExtended, positionExtended(0x00, 1, 1<<40), Position{Partition: 1, Block: 1 << 40}, nil},
+		{"extended EOP BPEW", PositionExtended, positionExtended(0x41, 0, 5), Position{Block: 5, EOP: true, BPEW: true}, nil},
+		{"extended LOLU", PositionExtended, positionExtended(0x04, 0, 0), Position{}, ErrPositionUnknown},
+
+		{"unknown form", 0x1f, make([]byte, 32), Position{}, errAny},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pos, err := parsePosition(tt.form, tt.dat)
+			switch {
+			case tt.err == nil && err != nil:
+				t.Fatal(err)
+			case tt.err != nil && err == nil:
+				t.Fatalf("got %v, want error", pos)
+			case tt.err != nil && tt.err != errAny && !errors.Is(err, tt.err):
+				t.Fatalf("error %v, want %v", err, tt.err)
+			}
+			if err == nil && pos != tt.want {
+				t.Fatalf("got %v, want %v", pos, tt.want)
+			}
+		})
+	}
+}
+
+func TestTellCache(t *testing.T) {
+	readPosition := func(block uint32) TraceRecord {
+		return TraceRecord{
+			CDB:      []byte{ScsiOpReadPosition, PositionShort, 0, 0, 0, 0, 0, 0, 0, 0},
+			Dir:      sgDxferFromDev,
+			DxferLen: 32,
+			DataIn:   positionShort(0, 0, block),
+		}
+	}
+	tr := &Trace{Commands: []TraceRecord{
+		readPosition(10),
+		{CDB: []byte{ScsiOpSpace6, SpaceBlocks, 0, 0, 2, 0}, Dir: sgDxferNone},
+		readPosition(12),
+		{CDB: []byte{ScsiOpRead, 0b10, 0, 0, 16, 0}, Dir: sgDxferFromDev, DxferLen: 16, DataIn: []byte("block")},
+		{CDB: []byte{ScsiOpTestUnitReady, 0, 0, 0, 0, 0}, Dir: sgDxferNone, Status: 0x02,
+			Sense: fixedSense(SenseKeyUnitAttention, 0x29, 0x00, false, 0)},
+		readPosition(0),
+	}}
+	d := Drive{direct: true, state: &driveState{notTape: true, blockSizeValid: true, replay: &replay{trace: tr}}}
+	d.SetRecoveryPolicy(NoRecovery)
+
+	tell := func(want uint64) {
+		t.Helper()
+		pos, err := d.Tell()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if pos.Block != want {
+			t.Fatalf("Tell: block %d, want %d", pos.Block, want)
+		}
+	}
+	tell(10)
+	tell(10) // cached, the trace has no second READ POSITION here
+	if err := d.SpaceBlocks(2); err != nil {
+		t.Fatal(err)
+	}
+	tell(12)
+	if _, err := d.ReadBlock(make([]byte, 16)); err != nil {
+		t.Fatal(err)
+	}
+	tell(13) // advanced over the block read
+	if err := d.TestUnitReady(); !errors.Is(err, ErrUnitAttention) {
+		t.Fatalf("TestUnitReady: %v", err)
+	}
+	tell(0) // reset, asked again
+}
@@ -0,0 +1,199 @@
+package tape
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+type RecoveryAction int
+
+const (
+	RecoverRetry     RecoveryAction = iota // command retried after UNIT ATTENTION
+	RecoverWaitReady                       // waiting for the drive becoming ready before retry
+	RecoverRestore                         // settings re-established after reset or medium change
+	RecoverGiveUp                          // condition is recoverable but the command is not retried
+)
+
+func (a RecoveryAction) String() string {
+	switch a {
+	case RecoverRetry:
+		return "retry"
+	case RecoverWaitReady:
+		return "wait ready"
+	case RecoverRestore:
+		return "restore"
+	case RecoverGiveUp:
+		return "give up"
+	}
+	return "unknown"
+}
+
+type RecoveryEvent struct {
+	Action  RecoveryAction
+	CDB     []byte
+	Sense   *SenseError
+	Attempt int           // attempts of the command so far
+	Delay   time.Duration // RecoverWaitReady only
+	Err     error         // RecoverRestore only, nil if restored
+}
+
+// RecoveryPolicy decides how SG_IO commands of a Drive recover from transient conditions.
+// Media and hardware errors are never retried. Commands moving relative to the current
+// position (READ, WRITE, SPACE, ...) are not retried after UNIT ATTENTION, as the position
+// may have been lost with it.
+type RecoveryPolicy struct {
+	UnitAttentionRetries int
+	// BecomingReady is the total time to wait on NOT READY, becoming ready (04/01)
+	BecomingReady  time.Duration
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// Restore re-establishes caller settings after reset, block size, encryption and
+	// logical block protection are restored before it
+	Restore func(d Drive) error
+	// Hook is called with every recovery action
+	Hook func(RecoveryEvent)
+}
+
+var DefaultRecoveryPolicy = RecoveryPolicy{
+	UnitAttentionRetries: 1,
+	BecomingReady:        2 * time.Minute,
+	InitialBackoff:       500 * time.Millisecond,
+	MaxBackoff:           10 * time.Second,
+}
+
+// NoRecovery returns every sense to the caller as is, settings lost with a reset are not restored
+var NoRecovery = RecoveryPolicy{}
+
+// restores reports whether settings are restored after reset, a policy retrying nothing and
+// without Restore leaves recovery to the caller entirely
+func (p *RecoveryPolicy) restores() bool {
+	return p.UnitAttentionRetries > 0 || p.Restore != nil
+}
+
+func (d Drive) SetRecoveryPolicy(p RecoveryPolicy) {
+	d.state.policy = &p
+}
+
+func (d Drive) recoveryPolicy() *RecoveryPolicy {
+	if d.state == nil || d.state.policy == nil {
+		return &DefaultRecoveryPolicy
+	}
+	return d.state.policy
+}
+
+func (p *RecoveryPolicy) event(e RecoveryEvent) {
+	if p.Hook != nil {
+		p.Hook(e)
+	}
+}
+
+// relativeOp reports whether op moves relative to the current position
+func relativeOp(op byte) bool {
+	switch op {
+	case ScsiOpRead, ScsiOpWrite, ScsiOpWriteFilemarks, ScsiOpSpace6, ScsiOpSpace16,
+		ScsiOpErase, ScsiOpVerify:
+		return true
+	}
+	return false
+}
+
+// recovery tracks the attempts of one command
+type recovery struct {
+	ctx       context.Context // waiting for the drive ends when it is done
+	cancelled bool
+	policy    *RecoveryPolicy
+	attempt   int
+	uaRetry   int
+	waited    time.Duration
+	backoff   time.Duration
+	restored  bool
+}
+
+// retry handles err of cdb and reports whether to issue it again
+func (d Drive) retry(rc *recovery, cdb []byte, err error) bool {
+	rc.attempt++
+	var s *SenseError
+	if !errors.As(err, &s) || d.state == nil || d.state.recovering || d.state.probing {
+		// a probe failing is tried again later, better than waiting for a loading drive
+		return false
+	}
+	p := rc.policy
+	ev := RecoveryEvent{CDB: cdb, Sense: s, Attempt: rc.attempt}
+	switch {
+	case s.Key == SenseKeyUnitAttention:
+		// the position may have been lost with whatever the drive reports
+		d.state.pos = nil
+		switch s.ASC {
+		case 0x28, 0x29: // medium may have changed, power on or reset
+			d.state.encryptVerified = false
+			d.state.partBlock = nil
+			d.state.blockSizeValid = false
+			if !rc.restored && p.restores() {
+				rc.restored = true
+				d.restore(p, ev)
+			}
+		case 0x2a: // parameters changed, encryption ones possibly by another host
+			d.state.blockSizeValid = false
+			d.state.encryptVerified = false
+		}
+		if rc.uaRetry >= p.UnitAttentionRetries || relativeOp(cdb[0]) {
+			ev.Action = RecoverGiveUp
+			p.event(ev)
+			return false
+		}
+		rc.uaRetry++
+		ev.Action = RecoverRetry
+		p.event(ev)
+		return true
+	case s.Key == SenseKeyNotReady && s.ASC == 0x04 && s.ASCQ == 0x01 && cdb[0] != ScsiOpTestUnitReady:
+		// TEST UNIT READY is how callers poll for long operations, leave it to them
+		if rc.waited >= p.BecomingReady {
+			if p.BecomingReady > 0 {
+				ev.Action = RecoverGiveUp
+				p.event(ev)
+			}
+			return false
+		}
+		if rc.backoff == 0 {
+			rc.backoff = p.InitialBackoff
+		}
+		ev.Action, ev.Delay = RecoverWaitReady, rc.backoff
+		p.event(ev)
+		t := time.NewTimer(rc.backoff)
+		select {
+		case <-rc.ctx.Done():
+			t.Stop()
+			rc.cancelled = true
+			return false
+		case <-t.C:
+		}
+		rc.waited += rc.backoff
+		rc.backoff = min(rc.backoff*2, max(p.MaxBackoff, p.InitialBackoff))
+		return true
+	}
+	return false
+}
+
+// restore re-applies the block size, encryption and protection set on d and then Restore of the policy
+func (d Drive) restore(p *RecoveryPolicy, ev RecoveryEvent) {
+	d.state.blockSizeValid = false
+	d.state.pos = nil
+	d.state.recovering = true
+	defer func() { d.state.recovering = false }()
+	var err error
+	if d.state.blockSizeSet {
+		err = d.SetBlockSize(d.state.wantBlockSize)
+	}
+	if err == nil && d.state.encryption != nil {
+		err = d.SetEncryption(*d.state.encryption)
+	}
+	if err == nil && d.state.lbp != LBPNone {
+		err = d.SetProtection(d.state.lbp)
+	}
+	if err == nil && p.Restore != nil {
+		err = p.Restore(d)
+	}
+	ev.Action, ev.Err = RecoverRestore, err
+	p.event(ev)
+}
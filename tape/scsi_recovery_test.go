@@ -0,0 +1,31 @@
+package tape
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBecomingReadyCancelled(t *testing.T) {
+	rec := TraceRecord{
+		CDB:    []byte{ScsiOpLocate10, Locate10FlagWithPart | 0x01, 0, 0, 0, 0, 5, 0, 0, 0},
+		Dir:    sgDxferNone,
+		Status: 0x02,
+		Sense:  fixedSense(SenseKeyNotReady, 0x04, 0x01, false, 0),
+	}
+	// the drive stays becoming ready for every retry
+	tr := &Trace{Commands: []TraceRecord{rec, rec, rec, rec}}
+	d := Drive{direct: true, state: &driveState{notTape: true, replay: &replay{trace: tr}}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err := d.LocateContext(ctx, 0, 5, nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("LocateContext: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed >= DefaultRecoveryPolicy.InitialBackoff {
+		t.Fatalf("LocateContext returned after %v, past the first backoff", elapsed)
+	}
+}
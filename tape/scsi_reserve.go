@@ -0,0 +1,204 @@
+package tape
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"syscall"
+)
+
+const scsiStatusReservationConflict = 0x18
+
+// ErrReservationConflict is returned for commands refused because another host holds a
+// persistent reservation. Errors of Drive commands are *ReservationConflictError naming
+// the holder when the drive reports it.
+var ErrReservationConflict = errors.New("reservation conflict")
+
+// ReservationConflictError is a command refused due to the reservation of Key
+type ReservationConflictError struct {
+	Key  uint64
+	Type byte
+}
+
+func (e *ReservationConflictError) Error() string {
+	return fmt.Sprintf("reservation conflict: reserved by key %#016x (%s)", e.Key, reservationTypeName(e.Type))
+}
+
+func (e *ReservationConflictError) Is(target error) bool { return target == ErrReservationConflict }
+
+// Service actions of PERSISTENT RESERVE IN
+const (
+	PRInReadKeys        = 0x00
+	PRInReadReservation = 0x01
+)
+
+// Service actions of PERSISTENT RESERVE OUT
+const (
+	PROutRegister               = 0x00
+	PROutReserve                = 0x01
+	PROutRelease                = 0x02
+	PROutClear                  = 0x03
+	PROutPreempt                = 0x04
+	PROutPreemptAndAbort        = 0x05
+	PROutRegisterAndIgnoreExist = 0x06
+)
+
+// Persistent reservation types
+const (
+	PRTypeWriteExclusive    = 0x1
+	PRTypeExclusiveAccess   = 0x3
+	PRTypeWriteExclusiveRO  = 0x5 // registrants only
+	PRTypeExclusiveAccessRO = 0x6 // registrants only
+	PRTypeWriteExclusiveAR  = 0x7 // all registrants
+	PRTypeExclusiveAccessAR = 0x8 // all registrants
+	prTypeUnknown           = 0x0
+)
+
+func reservationTypeName(t byte) string {
+	switch t {
+	case PRTypeWriteExclusive:
+		return "write exclusive"
+	case PRTypeExclusiveAccess:
+		return "exclusive access"
+	case PRTypeWriteExclusiveRO:
+		return "write exclusive, registrants only"
+	case PRTypeExclusiveAccessRO:
+		return "exclusive access, registrants only"
+	case PRTypeWriteExclusiveAR:
+		return "write exclusive, all registrants"
+	case PRTypeExclusiveAccessAR:
+		return "exclusive access, all registrants"
+	}
+	return fmt.Sprintf("type %#x", t)
+}
+
+// Reservations is the persistent reservation state of the drive
+type Reservations struct {
+	Generation uint32
+	Keys       []uint64 // registered by all hosts
+	Reserved   bool
+	Key        uint64 // of the holder
+	Type       byte
+}
+
+func (d Drive) persistentReserveIn(sa byte) ([]byte, error) {
+	recvLen := uint32(4096)
+	dat, err := d.scsiRead([]byte{
+		ScsiOpPersistentReserveIn, sa & 0x1f,
+		0, 0, 0, 0, 0,
+		byte(recvLen >> 8), byte(recvLen),
+		0,
+	}, recvLen, 60_000)
+	if err != nil {
+		return nil, err
+	}
+	if len(dat) < 8 {
+		return nil, fmt.Errorf("persistent reserve in: short data %d", len(dat))
+	}
+	n := int(binary.BigEndian.Uint32(dat[4:])) + 8
+	return dat[:min(n, len(dat))], nil
+}
+
+// ReadReservations reads the registered keys and the reservation holding the drive
+func (d Drive) ReadReservations() (Reservations, error) {
+	var r Reservations
+	dat, err := d.persistentReserveIn(PRInReadKeys)
+	if err != nil {
+		return r, err
+	}
+	for b := dat[8:]; len(b) >= 8; b = b[8:] {
+		r.Keys = append(r.Keys, binary.BigEndian.Uint64(b))
+	}
+	dat, err = d.persistentReserveIn(PRInReadReservation)
+	if err != nil {
+		return r, err
+	}
+	r.Generation = binary.BigEndian.Uint32(dat)
+	if len(dat) >= 24 {
+		r.Reserved = true
+		r.Key = binary.BigEndian.Uint64(dat[8:])
+		r.Type = dat[21] & 0x0f
+	}
+	return r, nil
+}
+
+// reservationConflict names the holder of the reservation a command ran into
+func (d Drive) reservationConflict() error {
+	r, err := d.ReadReservations()
+	if err != nil || !r.Reserved {
+		return ErrReservationConflict
+	}
+	return &ReservationConflictError{Key: r.Key, Type: r.Type}
+}
+
+// stConflict returns *ReservationConflictError for EIO or EBUSY of st if another key holds
+// the reservation, st reports RESERVATION CONFLICT as either
+func (d Drive) stConflict(err error) error {
+	if !errors.Is(err, syscall.EIO) && !errors.Is(err, syscall.EBUSY) {
+		return err
+	}
+	r, rerr := d.ReadReservations()
+	if rerr != nil || !r.Reserved || r.Key == d.state.reserveKey {
+		return err
+	}
+	return &ReservationConflictError{Key: r.Key, Type: r.Type}
+}
+
+// SetReservationKey tells d the key this host reserved the drive with on another node of it,
+// so errors of st commands are not taken for conflicts with its own reservation
+func (d Drive) SetReservationKey(key uint64) {
+	d.state.reserveKey = key
+}
+
+func (d Drive) persistentReserveOut(sa, typ byte, key, saKey uint64) error {
+	buf := make([]byte, 24)
+	binary.BigEndian.PutUint64(buf, key)
+	binary.BigEndian.PutUint64(buf[8:], saKey)
+	return d.scsiWrite([]byte{
+		ScsiOpPersistentReserveOut, sa & 0x1f,
+		typ & 0x0f, // scope LU
+		0, 0,
+		0, 0, 0, byte(len(buf)),
+		0,
+	}, buf, 60_000)
+}
+
+// Reserve registers key for this host and takes an exclusive access reservation with it.
+// A reservation of another host is returned as *ReservationConflictError, key is not left
+// registered then.
+func (d Drive) Reserve(key uint64) error {
+	if key == 0 {
+		return errors.New("reserve: key must not be 0")
+	}
+	err := d.persistentReserveOut(PROutRegisterAndIgnoreExist, prTypeUnknown, 0, key)
+	if err != nil {
+		return err
+	}
+	err = d.persistentReserveOut(PROutReserve, PRTypeExclusiveAccess, key, 0)
+	if err != nil {
+		d.persistentReserveOut(PROutRegister, prTypeUnknown, key, 0)
+		return err
+	}
+	d.state.reserveKey = key
+	return nil
+}
+
+// Release releases the reservation taken with key and unregisters it
+func (d Drive) Release(key uint64) error {
+	err := d.persistentReserveOut(PROutRelease, PRTypeExclusiveAccess, key, 0)
+	if err != nil {
+		return err
+	}
+	d.state.reserveKey = 0
+	return d.persistentReserveOut(PROutRegister, prTypeUnknown, key, 0)
+}
+
+// Preempt takes over the reservation of victim with key, removing the registration of victim.
+// It is meant for reservations left behind by a host that died holding them.
+func (d Drive) Preempt(key, victim uint64) error {
+	err := d.persistentReserveOut(PROutRegisterAndIgnoreExist, prTypeUnknown, 0, key)
+	if err != nil {
+		return err
+	}
+	return d.persistentReserveOut(PROutPreempt, PRTypeExclusiveAccess, key, victim)
+}
@@ -0,0 +1,70 @@
+package tape
+
+import (
+	"encoding/binary"
+	"errors"
+	"syscall"
+	"testing"
+)
+
+func prInRecord(sa byte, dat []byte) TraceRecord {
+	return TraceRecord{
+		CDB:      []byte{ScsiOpPersistentReserveIn, sa, 0, 0, 0, 0, 0, 0x10, 0x00, 0},
+		Dir:      sgDxferFromDev,
+		DxferLen: 4096,
+		DataIn:   dat,
+	}
+}
+
+// reservationRecords answers ReadReservations with holder registered and holding the
+// reservation, none if holder is 0
+func reservationRecords(holder uint64) []TraceRecord {
+	keys := make([]byte, 8)
+	res := make([]byte, 8)
+	if holder != 0 {
+		keys = binary.BigEndian.AppendUint64(keys, holder)
+		res = binary.BigEndian.AppendUint64(res, holder)
+		res = append(res, make([]byte, 8)...)
+		res[21] = PRTypeExclusiveAccess
+	}
+	binary.BigEndian.PutUint32(keys[4:], uint32(len(keys)-8))
+	binary.BigEndian.PutUint32(res[4:], uint32(len(res)-8))
+	return []TraceRecord{prInRecord(PRInReadKeys, keys), prInRecord(PRInReadReservation, res)}
+}
+
+func TestSTReservationConflict(t *testing.T) {
+	const own, other = 0x1111, 0x2222
+	tests := []struct {
+		name   string
+		err    error
+		own    uint64
+		cmds   []TraceRecord
+		holder uint64 // of the *ReservationConflictError expected, err is returned as is if 0
+	}{
+		{"EIO reserved by another host", syscall.EIO, own, reservationRecords(other), other},
+		{"EBUSY reserved by another host", syscall.EBUSY, 0, reservationRecords(other), other},
+		{"EIO own reservation", syscall.EIO, own, reservationRecords(own), 0},
+		{"EIO not reserved", syscall.EIO, own, reservationRecords(0), 0},
+		{"EIO reservations unreadable", syscall.EIO, own, nil, 0},
+		{"ENOSPC", syscall.ENOSPC, own, nil, 0},
+		{"no error", nil, own, nil, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := Drive{state: &driveState{notTape: true, replay: &replay{trace: &Trace{Commands: tt.cmds}}}}
+			d.SetRecoveryPolicy(NoRecovery)
+			d.SetReservationKey(tt.own)
+			err := d.stConflict(tt.err)
+			var rc *ReservationConflictError
+			if tt.holder == 0 {
+				if err != tt.err {
+					t.Fatalf("got %v, want %v", err, tt.err)
+				}
+				return
+			}
+			if !errors.As(err, &rc) || rc.Key != tt.holder || !errors.Is(err, ErrReservationConflict) {
+				t.Fatalf("got %v, want conflict with %#x", err, tt.holder)
+			}
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package tape
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrEarlyWarning is returned by WriteBlock and WriteFilemarks of Drive when the data was
+// written but the medium is past its early warning point. There is room left only to
+// finish the current file and index, further writes may fail with ErrEOM.
+var ErrEarlyWarning = errors.New("early warning: approaching end of medium")
+
+const rwTimeout = 600_000
+
+// BlockSize returns the fixed block length of the drive, 0 in variable block mode
+func (d Drive) BlockSize() (uint32, error) {
+	if d.state.blockSizeValid {
+		return d.state.blockSize, nil
+	}
+	md, err := d.ModeSense(ModePageDeviceConfiguration, 0, ModePCCurrent)
+	if err != nil {
+		return 0, err
+	}
+	if len(md.Blocks) == 0 {
+		return 0, errors.New("block size: no block descriptor")
+	}
+	d.state.blockSize, d.state.blockSizeValid = md.Blocks[0].BlockLength, true
+	return d.state.blockSize, nil
+}
+
+// SetBlockSize switches to fixed blocks of n bytes, or variable block mode if n is 0
+func (d Drive) SetBlockSize(n uint32) error {
+	d.state.blockSizeValid = false
+	d.state.blockSizeSet, d.state.wantBlockSize = true, n
+	if !d.direct {
+		return d.MTSetBlock(int32(n))
+	}
+	md, err := d.ModeSense(ModePageDeviceConfiguration, 0, ModePCCurrent)
+	if err != nil {
+		return err
+	}
+	var density byte
+	if len(md.Blocks) > 0 {
+		density = md.Blocks[0].Density
+	}
+	md.Blocks = []BlockDescriptor{{Density: density, BlockLength: n}}
+	md.Page = nil
+	err = d.ModeSelect(md, false)
+	if err != nil {
+		return err
+	}
+	d.state.blockSize, d.state.blockSizeValid = n, true
+	return nil
+}
+
+// rwCDB builds READ(6)/WRITE(6) for n bytes, counting blocks in fixed block mode
+func (d Drive) rwCDB(op byte, n int) ([]byte, error) {
+	bs := int(d.state.blockSize)
+	var flags byte
+	if bs == 0 {
+		if op == ScsiOpRead {
+			flags = 0b10 // SILI, shorter blocks are reported by resid only
+		}
+	} else {
+		if n%bs != 0 {
+			return nil, fmt.Errorf("%d bytes is not a multiple of block size %d", n, bs)
+		}
+		n /= bs
+		flags = 0b01 // FIXED
+	}
+	if n == 0 || n > 0xffffff {
+		return nil, fmt.Errorf("transfer length %d out of range", n)
+	}
+	return []byte{op, flags, byte(n >> 16), byte(n >> 8), byte(n), 0}, nil
+}
+
+// readBlockSG reads by READ(6), in fixed block mode as many whole blocks as buf holds
+func (d Drive) readBlockSG(buf []byte) (int, error) {
+	if bs := int(d.state.blockSize); bs != 0 {
+		buf = buf[:len(buf)/bs*bs]
+	}
+	cdb, err := d.rwCDB(ScsiOpRead, len(buf))
+	if err != nil {
+		return 0, err
+	}
+	prev := d.state.pos
+	n, err := d.scsiReadInto(cdb, buf, rwTimeout)
+	switch {
+	case err == nil:
+		d.advance(prev, d.blocks(n), 0, false)
+	case errors.Is(err, ErrFilemark):
+		// in fixed block mode blocks before the filemark are returned with it
+		var blocks uint64
+		if n > 0 {
+			blocks = d.blocks(n)
+		}
+		d.advance(prev, blocks, 1, false)
+		return n, io.EOF
+	}
+	return n, err
+}
+
+// blocks counts the blocks of n bytes transferred by one READ or WRITE
+func (d Drive) blocks(n int) uint64 {
+	if bs := d.state.blockSize; bs != 0 {
+		return uint64(n) / uint64(bs)
+	}
+	return 1
+}
+
+func (d Drive) writeBlockSG(buf []byte) error {
+	cdb, err := d.rwCDB(ScsiOpWrite, len(buf))
+	if err != nil {
+		return err
+	}
+	prev := d.state.pos
+	err = earlyWarning(d.scsiWrite(cdb, buf, rwTimeout))
+	if ew := errors.Is(err, ErrEarlyWarning); err == nil || ew {
+		d.advance(prev, d.blocks(len(buf)), 0, ew)
+	}
+	return err
+}
+
+func (d Drive) writeFilemarksSG(count int32) error {
+	if count < 0 || count > 0xffffff {
+		return fmt.Errorf("write filemarks: count %d out of range", count)
+	}
+	prev := d.state.pos
+	err := earlyWarning(d.scsiCmd([]byte{
+		ScsiOpWriteFilemarks, 0,
+		byte(count >> 16), byte(count >> 8), byte(count),
+		0,
+	}, rwTimeout))
+	if ew := errors.Is(err, ErrEarlyWarning); err == nil || ew {
+		d.advance(prev, 0, uint64(count), ew)
+	}
+	return err
+}
+
+// Code field of SPACE
+const (
+	SpaceBlocks    = 0b000
+	SpaceFilemarks = 0b001
+	SpaceEOD       = 0b011
+)
+
+// Space spaces over count blocks or filemarks by SPACE(6), backward if count is negative
+func (d Drive) Space(code byte, count int32) error {
+	if count < -0x800000 || count > 0x7fffff {
+		return fmt.Errorf("space: count %d out of range", count)
+	}
+	return d.scsiCmd([]byte{
+		ScsiOpSpace6, code & 0b111,
+		byte(count >> 16), byte(count >> 8), byte(count),
+		0,
+	}, rwTimeout)
+}
+
+// earlyWarning turns EOM reported with a completed write into ErrEarlyWarning
+func earlyWarning(err error) error {
+	var s *SenseError
+	if errors.As(err, &s) && s.EOM && (s.Key == SenseKeyNoSense || s.Key == SenseKeyRecoveredError) {
+		return fmt.Errorf("%w: %w", ErrEarlyWarning, err)
+	}
+	return err
+}
@@ -0,0 +1,134 @@
+package tape
+
+import (
+	"encoding/binary"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// senseFixed builds fixed format sense, information valid if info is not 0
+func senseFixed(flagsKey byte, info int32, asc, ascq byte, sks [3]byte) []byte {
+	b := make([]byte, 18)
+	b[0] = 0x70
+	if info != 0 {
+		b[0] |= 0x80
+	}
+	b[2] = flagsKey
+	binary.BigEndian.PutUint32(b[3:], uint32(info))
+	b[7] = 10
+	b[12], b[13] = asc, ascq
+	copy(b[15:], sks[:])
+	return b
+}
+
+// senseDesc builds descriptor format sense with descs
+func senseDesc(key, asc, ascq byte, descs ...[]byte) []byte {
+	b := []byte{0x72, key, asc, ascq, 0, 0, 0, 0}
+	for _, d := range descs {
+		b = append(b, d...)
+	}
+	b[7] = byte(len(b) - 8)
+	return b
+}
+
+func TestParseSense(t *testing.T) {
+	const (
+		fm  = 0x80
+		eom = 0x40
+		ili = 0x20
+	)
+	var noSKS [3]byte
+	infoDesc := func(info int64) []byte {
+		return binary.BigEndian.AppendUint64([]byte{0x00, 0x0a, 0x80, 0}, uint64(info))
+	}
+	tests := []struct {
+		name     string
+		sense    []byte
+		key      byte
+		asc      byte
+		ascq     byte
+		info     int64 // information must be valid if not 0
+		flags    string
+		progress float64 // checked if not 0
+		is       []error
+		not      []error
+	}{
+		{name: "fixed ILI of a larger block", sense: senseFixed(ili, -1024, 0, 0, noSKS),
+			info: -1024, flags: "I", not: []error{ErrFilemark, ErrEOM}},
+		{name: "fixed filemark", sense: senseFixed(fm, 64, 0x00, 0x01, noSKS),
+			ascq: 0x01, info: 64, flags: "F", is: []error{ErrFilemark}, not: []error{ErrEOD}},
+		{name: "fixed early warning", sense: senseFixed(eom, 0, 0x00, 0x02, noSKS),
+			ascq: 0x02, flags: "E", is: []error{ErrEOM}, not: []error{ErrBlankCheck}},
+		{name: "fixed EOD", sense: senseFixed(SenseKeyBlankCheck, 5, 0x00, 0x05, noSKS),
+			key: SenseKeyBlankCheck, ascq: 0x05, info: 5, is: []error{ErrBlankCheck, ErrEOD}, not: []error{ErrEOM}},
+		{name: "fixed space backward into BOP", sense: senseFixed(eom, -2, 0x00, 0x04, noSKS),
+			ascq: 0x04, info: -2, flags: "E", is: []error{ErrBOP, ErrEOM}},
+		{name: "fixed becoming ready", sense: senseFixed(SenseKeyNotReady, 0, 0x04, 0x01, [3]byte{0x80, 0x80, 0x00}),
+			key: SenseKeyNotReady, asc: 0x04, ascq: 0x01, progress: 0.5, is: []error{ErrNotReady}},
+		{name: "fixed medium error", sense: senseFixed(SenseKeyMediumError, 0, 0x11, 0x00, noSKS),
+			key: SenseKeyMediumError, asc: 0x11, is: []error{ErrMediumError}, not: []error{ErrHardwareError}},
+		{name: "fixed write protected", sense: senseFixed(SenseKeyDataProtect, 0, 0x27, 0x00, noSKS),
+			key: SenseKeyDataProtect, asc: 0x27, is: []error{ErrWriteProtected}},
+		{name: "fixed without ASC", sense: []byte{0x70, 0, SenseKeyUnitAttention, 0, 0, 0, 0},
+			key: SenseKeyUnitAttention, is: []error{ErrUnitAttention}},
+		{name: "descriptor filemark", sense: senseDesc(SenseKeyNoSense, 0x00, 0x01, infoDesc(-2), []byte{0x04, 0x02, 0, fm}),
+			ascq: 0x01, info: -2, flags: "F", is: []error{ErrFilemark}},
+		{name: "descriptor large information", sense: senseDesc(SenseKeyBlankCheck, 0x00, 0x05, infoDesc(1<<40)),
+			key: SenseKeyBlankCheck, ascq: 0x05, info: 1 << 40, is: []error{ErrEOD}},
+		{name: "descriptor becoming ready", sense: senseDesc(SenseKeyNotReady, 0x04, 0x01, []byte{0x02, 0x06, 0, 0, 0x80, 0x40, 0x00, 0}),
+			key: SenseKeyNotReady, asc: 0x04, ascq: 0x01, progress: 0.25, is: []error{ErrNotReady}},
+		{name: "descriptor EOM ILI", sense: senseDesc(SenseKeyVolumeOverflow, 0x00, 0x02, []byte{0x04, 0x02, 0, eom | ili}),
+			key: SenseKeyVolumeOverflow, ascq: 0x02, flags: "EI", is: []error{ErrEOM}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := ParseSense(tt.sense)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if s.Key != tt.key || s.ASC != tt.asc || s.ASCQ != tt.ascq {
+				t.Errorf("sense %x/%02x/%02x, want %x/%02x/%02x", s.Key, s.ASC, s.ASCQ, tt.key, tt.asc, tt.ascq)
+			}
+			if s.InfoValid != (tt.info != 0) || int64(s.Information) != tt.info {
+				t.Errorf("information %v %d, want %d", s.InfoValid, int64(s.Information), tt.info)
+			}
+			var flags string
+			if s.Filemark {
+				flags += "F"
+			}
+			if s.EOM {
+				flags += "E"
+			}
+			if s.ILI {
+				flags += "I"
+			}
+			if flags != tt.flags {
+				t.Errorf("flags %q, want %q", flags, tt.flags)
+			}
+			if p, ok := s.Progress(); tt.progress != 0 && (!ok || p != tt.progress) {
+				t.Errorf("progress %v %v, want %v", p, ok, tt.progress)
+			}
+			for _, e := range tt.is {
+				if !errors.Is(s, e) {
+					t.Errorf("is not %v", e)
+				}
+			}
+			for _, e := range tt.not {
+				if errors.Is(s, e) {
+					t.Errorf("is %v", e)
+				}
+			}
+		})
+	}
+
+	s, _ := ParseSense(senseFixed(ili, -1024, 0, 0, noSKS))
+	if !strings.Contains(s.Error(), "info=-1024") {
+		t.Errorf("Error() = %q", s.Error())
+	}
+	for _, b := range [][]byte{nil, {0x70, 0}, {0x72, 0, 0}, {0x7f, 0, 0, 0, 0, 0, 0, 0}} {
+		if s, err := ParseSense(b); err == nil {
+			t.Errorf("ParseSense(%x) = %v, want error", b, s)
+		}
+	}
+}
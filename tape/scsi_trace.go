@@ -0,0 +1,373 @@
+package tape
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+/*
+Trace file format, all integers big endian:
+
+	"SGTRACE1"
+	records of
+		kind     u8      'O' open, 'C' command
+		length   u32     of the rest of the record
+	open
+		flags    u8      bit0 Direct
+	command
+		cdb      u8 len, bytes
+		dir      i8      sgDxfer*
+		dxferLen u32
+		dataOut  u32 len, bytes
+		dataIn   u32 len, bytes (dxferLen - resid)
+		resid    i32
+		status   u8
+		host     u16
+		driver   u16
+		sense    u8 len, bytes
+		duration u32     microseconds
+		errno    u32     of the SG_IO ioctl, 0 if none
+*/
+
+const traceMagic = "SGTRACE1"
+
+const (
+	traceOpen    = 'O'
+	traceCommand = 'C'
+)
+
+// Tracer records every SG_IO command of a Drive to a trace file.
+// Operations done by the st driver (read/write and MT ioctls on st nodes) are not SCSI
+// commands of this process and are not recorded, trace with OpenOptions.Direct to get them.
+// Keys of SetEncryption are recorded zeroed.
+type Tracer struct {
+	mu  sync.Mutex
+	w   *bufio.Writer
+	c   io.Closer
+	err error
+}
+
+func NewTracer(w io.Writer) (*Tracer, error) {
+	t := &Tracer{w: bufio.NewWriter(w)}
+	if c, ok := w.(io.Closer); ok {
+		t.c = c
+	}
+	_, err := t.w.WriteString(traceMagic)
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func CreateTrace(path string) (*Tracer, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	t, err := NewTracer(f)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return t, nil
+}
+
+// Err returns the first error writing the trace
+func (t *Tracer) Err() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.err
+}
+
+func (t *Tracer) Close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	err := t.w.Flush()
+	if t.c != nil {
+		if cerr := t.c.Close(); err == nil {
+			err = cerr
+		}
+	}
+	if t.err != nil {
+		return t.err
+	}
+	return err
+}
+
+func (t *Tracer) write(kind byte, rec []byte) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.err != nil {
+		return
+	}
+	var hdr [5]byte
+	hdr[0] = kind
+	binary.BigEndian.PutUint32(hdr[1:], uint32(len(rec)))
+	if _, err := t.w.Write(hdr[:]); err != nil {
+		t.err = err
+		return
+	}
+	if _, err := t.w.Write(rec); err != nil {
+		t.err = err
+	}
+}
+
+func (t *Tracer) open(direct bool) {
+	var flags byte
+	if direct {
+		flags |= 1
+	}
+	t.write(traceOpen, []byte{flags})
+}
+
+func appendBytes32(b, v []byte) []byte {
+	b = binary.BigEndian.AppendUint32(b, uint32(len(v)))
+	return append(b, v...)
+}
+
+// redactKey returns data-out of cdb with the key zeroed if cdb sets data encryption
+// parameters, a trace is no place for it
+func redactKey(cdb, data []byte) []byte {
+	if len(cdb) < 4 || cdb[0] != ScsiOpSecurityProtocolOut || cdb[1] != SecurityProtocolTapeEncryption ||
+		binary.BigEndian.Uint16(cdb[2:]) != EncryptionPageSet || len(data) < 20 {
+		return data
+	}
+	n := min(int(binary.BigEndian.Uint16(data[18:])), len(data)-20)
+	data = bytes.Clone(data)
+	clear(data[20 : 20+n])
+	return data
+}
+
+// record appends the completed hdr with the error of the ioctl
+func (t *Tracer) record(hdr *sgioHdr, err error, dur time.Duration) {
+	var dataOut, dataIn []byte
+	if hdr.dxferLen > 0 {
+		data := unsafe.Slice((*byte)(hdr.dxferp), hdr.dxferLen)
+		switch hdr.dxferDirection {
+		case sgDxferToDev:
+			dataOut = redactKey(unsafe.Slice((*byte)(hdr.cmdp), hdr.cmdLen), data)
+		case sgDxferFromDev:
+			n := int(hdr.dxferLen) - int(hdr.resid)
+			if n >= 0 && n <= len(data) {
+				dataIn = data[:n]
+			}
+		}
+	}
+	var errno syscall.Errno
+	errors.As(err, &errno)
+
+	b := []byte{hdr.cmdLen}
+	b = append(b, unsafe.Slice((*byte)(hdr.cmdp), hdr.cmdLen)...)
+	b = append(b, byte(int8(hdr.dxferDirection)))
+	b = binary.BigEndian.AppendUint32(b, hdr.dxferLen)
+	b = appendBytes32(b, dataOut)
+	b = appendBytes32(b, dataIn)
+	b = binary.BigEndian.AppendUint32(b, uint32(hdr.resid))
+	b = append(b, hdr.status)
+	b = binary.BigEndian.AppendUint16(b, hdr.hostStatus)
+	b = binary.BigEndian.AppendUint16(b, hdr.driverStatus)
+	b = append(b, hdr.sbLenWr)
+	if hdr.sbLenWr > 0 {
+		b = append(b, unsafe.Slice((*byte)(hdr.sbp), hdr.sbLenWr)...)
+	}
+	b = binary.BigEndian.AppendUint32(b, uint32(dur.Microseconds()))
+	b = binary.BigEndian.AppendUint32(b, uint32(errno))
+	t.write(traceCommand, b)
+}
+
+// SetTracer records the SG_IO commands of d to t, nil stops recording
+func (d Drive) SetTracer(t *Tracer) {
+	d.state.tracer = t
+}
+
+// TraceRecord is a recorded command
+type TraceRecord struct {
+	CDB      []byte
+	Dir      int8
+	DxferLen uint32
+	DataOut  []byte
+	DataIn   []byte
+	Resid    int32
+	Status   byte
+	Host     uint16
+	Driver   uint16
+	Sense    []byte
+	Duration time.Duration
+	Errno    syscall.Errno
+}
+
+type traceReader struct {
+	b   []byte
+	err error
+}
+
+func (r *traceReader) next(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if len(r.b) < n {
+		r.err = io.ErrUnexpectedEOF
+		return nil
+	}
+	v := r.b[:n]
+	r.b = r.b[n:]
+	return v
+}
+
+func (r *traceReader) u8() byte {
+	if v := r.next(1); v != nil {
+		return v[0]
+	}
+	return 0
+}
+
+func (r *traceReader) u16() uint16 {
+	if v := r.next(2); v != nil {
+		return binary.BigEndian.Uint16(v)
+	}
+	return 0
+}
+
+func (r *traceReader) u32() uint32 {
+	if v := r.next(4); v != nil {
+		return binary.BigEndian.Uint32(v)
+	}
+	return 0
+}
+
+func parseTraceRecord(b []byte) (TraceRecord, error) {
+	r := &traceReader{b: b}
+	var rec TraceRecord
+	rec.CDB = r.next(int(r.u8()))
+	rec.Dir = int8(r.u8())
+	rec.DxferLen = r.u32()
+	rec.DataOut = r.next(int(r.u32()))
+	rec.DataIn = r.next(int(r.u32()))
+	rec.Resid = int32(r.u32())
+	rec.Status = r.u8()
+	rec.Host = r.u16()
+	rec.Driver = r.u16()
+	rec.Sense = r.next(int(r.u8()))
+	rec.Duration = time.Duration(r.u32()) * time.Microsecond
+	rec.Errno = syscall.Errno(r.u32())
+	return rec, r.err
+}
+
+// Trace is a recorded trace file
+type Trace struct {
+	Direct   bool // recorded from a Drive opened with OpenOptions.Direct
+	Commands []TraceRecord
+}
+
+func ReadTrace(path string) (*Trace, error) {
+	dat, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTrace(dat)
+}
+
+func ParseTrace(dat []byte) (*Trace, error) {
+	if !bytes.HasPrefix(dat, []byte(traceMagic)) {
+		return nil, errors.New("trace: bad magic")
+	}
+	dat = dat[len(traceMagic):]
+	tr := new(Trace)
+	for len(dat) > 0 {
+		if len(dat) < 5 {
+			return tr, fmt.Errorf("trace: truncated record header")
+		}
+		kind, n := dat[0], int(binary.BigEndian.Uint32(dat[1:]))
+		if len(dat) < 5+n {
+			return tr, fmt.Errorf("trace: truncated record %d", len(tr.Commands))
+		}
+		rec := dat[5 : 5+n]
+		dat = dat[5+n:]
+		switch kind {
+		case traceOpen:
+			tr.Direct = len(rec) > 0 && rec[0]&1 != 0
+		case traceCommand:
+			c, err := parseTraceRecord(rec)
+			if err != nil {
+				return tr, fmt.Errorf("trace: record %d: %w", len(tr.Commands), err)
+			}
+			tr.Commands = append(tr.Commands, c)
+		}
+	}
+	return tr, nil
+}
+
+// replay serves recorded commands in order in place of the device
+type replay struct {
+	trace *Trace
+	next  int
+}
+
+func (r *replay) serve(hdr *sgioHdr) error {
+	cdb := unsafe.Slice((*byte)(hdr.cmdp), hdr.cmdLen)
+	if r.next >= len(r.trace.Commands) {
+		return fmt.Errorf("replay: trace exhausted at cmd=%x", cdb)
+	}
+	rec := r.trace.Commands[r.next]
+	if !bytes.Equal(cdb, rec.CDB) {
+		return fmt.Errorf("replay: command %d is cmd=%x, trace has cmd=%x", r.next, cdb, rec.CDB)
+	}
+	// keys are compared as recorded, redacted
+	if hdr.dxferDirection == sgDxferToDev && hdr.dxferLen > 0 &&
+		!bytes.Equal(redactKey(cdb, unsafe.Slice((*byte)(hdr.dxferp), hdr.dxferLen)), redactKey(cdb, rec.DataOut)) {
+		return fmt.Errorf("replay: command %d cmd=%x data-out differs from trace", r.next, cdb)
+	}
+	r.next++
+	if hdr.dxferDirection == sgDxferFromDev && hdr.dxferLen > 0 {
+		copy(unsafe.Slice((*byte)(hdr.dxferp), hdr.dxferLen), rec.DataIn)
+	}
+	hdr.resid = int32(hdr.dxferLen) - int32(min(len(rec.DataIn), int(hdr.dxferLen)))
+	if hdr.dxferDirection != sgDxferFromDev {
+		hdr.resid = rec.Resid
+	}
+	hdr.status, hdr.hostStatus, hdr.driverStatus = rec.Status, rec.Host, rec.Driver
+	hdr.sbLenWr = byte(copy(unsafe.Slice((*byte)(hdr.sbp), hdr.mxSbLen), rec.Sense))
+	hdr.duration = uint32(rec.Duration.Milliseconds())
+	if rec.Errno != 0 {
+		return rec.Errno
+	}
+	return nil
+}
+
+// OpenReplay opens a trace file as a Drive that answers with the recorded commands.
+// Commands must come in the recorded order with the same CDB and data-out,
+// otherwise they fail with an error naming the first difference.
+func OpenReplay(path string) (*Drive, error) {
+	tr, err := ReadTrace(path)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	d := &Drive{
+		File:   f,
+		fd:     f.Fd(),
+		direct: true,
+		state:  &driveState{replay: &replay{trace: tr}},
+	}
+	if tr.Direct {
+		// as OpenWith did when recording
+		_, err = d.BlockSize()
+		if err != nil {
+			f.Close()
+			return nil, err
+		}
+	}
+	return d, nil
+}
@@ -0,0 +1,163 @@
+package tape
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"path/filepath"
+	"testing"
+	"time"
+	"unsafe"
+)
+
+// traceCmd records cdb as completed with data and sense, the status is CHECK CONDITION with sense
+func traceCmd(tr *Tracer, cdb []byte, dir int32, data []byte, resid int32, sense []byte) {
+	hdr := newScsiCmd(cdb, 0)
+	hdr.dxferDirection = dir
+	if len(data) > 0 {
+		hdr.dxferLen = uint32(len(data))
+		hdr.dxferp = unsafe.Pointer(&data[0])
+	}
+	hdr.resid = resid
+	if len(sense) > 0 {
+		hdr.status = 0x02
+		hdr.sbLenWr = byte(copy(unsafe.Slice((*byte)(hdr.sbp), hdr.mxSbLen), sense))
+	}
+	tr.record(&hdr, nil, time.Millisecond)
+}
+
+func fixedSense(key, asc, ascq byte, filemark bool, info uint32) []byte {
+	s := make([]byte, 18)
+	s[0] = 0xf0 // current, information valid
+	s[2] = key
+	if filemark {
+		s[2] |= 0x80
+	}
+	s[3], s[4], s[5], s[6] = byte(info>>24), byte(info>>16), byte(info>>8), byte(info)
+	s[7] = 10
+	s[12], s[13] = asc, ascq
+	return s
+}
+
+func TestTraceReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "drive.sgtrace")
+	tr, err := CreateTrace(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr.open(false)
+
+	inq := make([]byte, 0xff)
+	copy(inq, []byte{PeripheralSequentialAccess, 0x80, 0x06, 0x02, 31})
+	copy(inq[8:], "IBM     ULT3580-TD8     Q3F4")
+	inqCDB := []byte{ScsiOpInquiry, 0, 0, 0, 0xff, 0}
+	// the capabilities probe of the first command
+	traceCmd(tr, inqCDB, sgDxferFromDev, inq, 0xff-36, nil)
+	traceCmd(tr, []byte{0xa3, 0x0c, 0x80, 0, 0, 0, 0, 0, 0x20, 0, 0, 0}, sgDxferFromDev, make([]byte, 8192), 8192,
+		fixedSense(SenseKeyIllegalRequest, 0x20, 0x00, false, 0))
+	traceCmd(tr, []byte{ScsiOpReadBlockLimits, 0, 0, 0, 0, 0}, sgDxferFromDev, []byte{0, 0x80, 0, 0, 0, 1}, 0, nil)
+
+	traceCmd(tr, inqCDB, sgDxferFromDev, inq, 0xff-36, nil)
+
+	readCDB := []byte{ScsiOpRead, 0b10, 0, 0, 64, 0}
+	block := make([]byte, 64)
+	copy(block, "hello")
+	traceCmd(tr, readCDB, sgDxferFromDev, block, 64-5, nil)
+	traceCmd(tr, readCDB, sgDxferFromDev, make([]byte, 64), 64,
+		fixedSense(SenseKeyNoSense, 0x00, 0x01, true, 64))
+	traceCmd(tr, []byte{ScsiOpTestUnitReady, 0, 0, 0, 0, 0}, sgDxferNone, nil, 0,
+		fixedSense(SenseKeyNotReady, 0x3a, 0x00, false, 0))
+	if err = tr.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	trace, err := ReadTrace(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if trace.Direct || len(trace.Commands) != 7 {
+		t.Fatalf("trace: direct %v, %d commands", trace.Direct, len(trace.Commands))
+	}
+	if rec := trace.Commands[4]; string(rec.DataIn) != "hello" || rec.Resid != 64-5 {
+		t.Fatalf("trace: READ has data %q resid %d", rec.DataIn, rec.Resid)
+	}
+
+	d, err := OpenReplay(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+	if err = d.TestUnitReady(); err == nil {
+		t.Fatal("TEST UNIT READY replayed in place of INQUIRY")
+	}
+	if caps, err := d.Capabilities(); err != nil || caps.MaxBlockLength != 8<<20 || caps.Commands != nil {
+		t.Fatalf("capabilities: %+v, %v", caps, err)
+	}
+	in, err := d.Inquiry()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if in.Vendor != "IBM" || in.Product != "ULT3580-TD8" || in.Revision != "Q3F4" {
+		t.Fatalf("inquiry: %+v", in)
+	}
+	buf := make([]byte, 64)
+	n, err := d.ReadBlock(buf)
+	if err != nil || string(buf[:n]) != "hello" {
+		t.Fatalf("read: %q, %v", buf[:n], err)
+	}
+	if n, err = d.ReadBlock(buf); n != 0 || err != io.EOF {
+		t.Fatalf("read at filemark: %d, %v", n, err)
+	}
+	err = d.TestUnitReady()
+	var s *SenseError
+	if !errors.As(err, &s) || !errors.Is(err, ErrNotReady) || s.ASC != 0x3a {
+		t.Fatalf("test unit ready: %v", err)
+	}
+	if err = d.TestUnitReady(); err == nil {
+		t.Fatal("replay went past the end of the trace")
+	}
+}
+
+func TestTraceRedactsKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "key.sgtrace")
+	tr, err := CreateTrace(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// nothing to replay, the command fails after it is recorded as issued
+	d := Drive{direct: true, state: &driveState{tracer: tr, replay: &replay{trace: &Trace{}}}}
+	key := bytes.Repeat([]byte{0xa5}, 32)
+	p := EncryptionParams{Encrypt: EncryptOn, Decrypt: DecryptMixed, Key: key, UKAD: []byte("tape1")}
+	if err = d.SetEncryption(p); err == nil {
+		t.Fatal("SetEncryption with an empty trace")
+	}
+	if err = tr.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	trace, err := ReadTrace(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out []byte
+	for _, rec := range trace.Commands {
+		if rec.CDB[0] == ScsiOpSecurityProtocolOut {
+			out = rec.DataOut
+		}
+	}
+	if len(out) < 52 {
+		t.Fatalf("data-out %x", out)
+	}
+	if bytes.Contains(out, key) || !bytes.Equal(out[20:52], make([]byte, 32)) || !bytes.HasSuffix(out, []byte("tape1")) {
+		t.Fatalf("data-out %x", out)
+	}
+
+	d2, err := OpenReplay(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d2.Close()
+	if err = d2.SetEncryption(p); err != nil {
+		t.Fatal(err)
+	}
+}
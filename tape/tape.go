@@ -1,23 +1,33 @@
 package tape
 
 import (
+	"errors"
 	"fmt"
 	"io"
+	"math"
+	"syscall"
 )
 
 const shortLimit = 1 << 20
 
-func (d Drive) MTMustReadShortFile() []byte {
-	b, err := d.MTReadShortFile()
+func (d Drive) MTMustReadShortFile() []byte { return MustReadShortFile(d) }
+
+func (d Drive) MTReadShortFile() ([]byte, error) { return ReadShortFile(d) }
+
+func (d Drive) MTReadFull(buf []byte) (n int, err error) { return ReadFull(d, buf) }
+
+func MustReadShortFile(dev Device) []byte {
+	b, err := ReadShortFile(dev)
 	if err != io.EOF {
 		panic(err)
 	}
 	return b
 }
 
-func (d Drive) MTReadShortFile() ([]byte, error) {
+// ReadShortFile reads blocks until the next filemark, up to shortLimit bytes
+func ReadShortFile(dev Device) ([]byte, error) {
 	b := make([]byte, shortLimit)
-	n, err := d.MTReadFull(b)
+	n, err := ReadFull(dev, b)
 	if err == nil {
 		return b, fmt.Errorf("file too big for %d bytes", shortLimit)
 	}
@@ -29,11 +39,123 @@ func (d Drive) MTReadShortFile() ([]byte, error) {
 	return b, err
 }
 
-func (d Drive) MTReadFull(buf []byte) (n int, err error) {
+// ReadFull reads blocks into buf until it is full or an error (io.EOF on filemark) occurs
+func ReadFull(dev Device, buf []byte) (n int, err error) {
 	for n < len(buf) && err == nil {
 		var nn int
-		nn, err = d.File.Read(buf[n:])
+		nn, err = dev.ReadBlock(buf[n:])
 		n += nn
 	}
 	return
 }
+
+func (d Drive) ReadBlock(buf []byte) (int, error) {
+	if d.state.lbp != LBPNone {
+		return d.readProtected(buf, d.readBlock)
+	}
+	return d.readBlock(buf)
+}
+
+func (d Drive) readBlock(buf []byte) (int, error) {
+	if d.direct {
+		return d.readBlockSG(buf)
+	}
+	d.state.pos = nil
+	n, err := d.File.Read(buf)
+	return n, d.stConflict(err)
+}
+
+// WriteBlock writes buf as one block, or as len(buf)/BlockSize blocks in fixed block mode.
+// ErrEarlyWarning is returned after the block was written past early warning.
+func (d Drive) WriteBlock(buf []byte) error {
+	if err := d.requireEncryption(); err != nil {
+		return err
+	}
+	if d.state.lbp != LBPNone {
+		var err error
+		if buf, err = d.protect(buf); err != nil {
+			return err
+		}
+	}
+	if d.direct {
+		return d.writeBlockSG(buf)
+	}
+	d.state.pos = nil
+	n, err := d.File.Write(buf)
+	if errors.Is(err, syscall.ENOSPC) {
+		// st refuses the first write past early warning and accepts the next one
+		n, err = d.File.Write(buf)
+		if err == nil && n == len(buf) {
+			return ErrEarlyWarning
+		}
+	}
+	if err == nil && n != len(buf) {
+		err = io.ErrShortWrite
+	}
+	return d.stConflict(err)
+}
+
+func (d Drive) WriteFilemarks(count int32) error {
+	if d.direct {
+		return d.writeFilemarksSG(count)
+	}
+	err := d.MTWEOF(count)
+	if errors.Is(err, syscall.ENOSPC) {
+		return ErrEarlyWarning
+	}
+	return err
+}
+
+func (d Drive) SpaceBlocks(count int32) error {
+	if d.direct {
+		return d.Space(SpaceBlocks, count)
+	}
+	if count < 0 {
+		return d.MTBSR(-count)
+	}
+	return d.MTFSR(count)
+}
+
+func (d Drive) SpaceFilemarks(count int32) error {
+	if d.direct {
+		return d.Space(SpaceFilemarks, count)
+	}
+	if count < 0 {
+		return d.MTBSF(-count)
+	}
+	return d.MTFSF(count)
+}
+
+func (d Drive) Locate(part int32, block uint64) error {
+	if d.direct {
+		return d.LocatePartBlock(byte(part), block)
+	}
+	if block > math.MaxInt32 {
+		// MTSEEK takes an int, open with OpenOptions.Direct to locate past it
+		return fmt.Errorf("locate: block %d out of range of st", block)
+	}
+	err := d.MTSwitchPart(part)
+	if err != nil {
+		return err
+	}
+	return d.MTSeek(int32(block))
+}
+
+func (d Drive) SwitchPartition(part int32) error {
+	if d.direct {
+		// st returns to the block last visited of part, do so too
+		pos, err := d.Tell()
+		if err != nil {
+			return err
+		}
+		if pos.Partition == uint32(part) {
+			return nil
+		}
+		if d.state.partBlock == nil {
+			d.state.partBlock = make(map[uint32]uint64)
+		}
+		d.state.partBlock[pos.Partition] = pos.Block
+		return d.LocatePartBlock(byte(part), d.state.partBlock[uint32(part)])
+	}
+	return d.MTSwitchPart(part)
+}
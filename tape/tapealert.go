@@ -0,0 +1,161 @@
+package tape
+
+import (
+	"fmt"
+	"log"
+)
+
+type TapeAlertSeverity byte
+
+const (
+	TapeAlertInfo TapeAlertSeverity = iota
+	TapeAlertWarning
+	TapeAlertCritical
+)
+
+func (s TapeAlertSeverity) String() string {
+	switch s {
+	case TapeAlertWarning:
+		return "warning"
+	case TapeAlertCritical:
+		return "critical"
+	}
+	return "info"
+}
+
+type TapeAlert struct {
+	Flag     int // 1 to 64
+	Name     string
+	Severity TapeAlertSeverity
+	Action   string // recommended application client message
+	Active   bool
+}
+
+func (a TapeAlert) String() string {
+	return fmt.Sprintf("TapeAlert %02Xh %s [%s]: %s", a.Flag, a.Name, a.Severity, a.Action)
+}
+
+type tapeAlertDef struct {
+	name     string
+	severity TapeAlertSeverity
+	action   string
+}
+
+// tapeAlertDefs is indexed by flag-1, see SSC-3 Annex A
+var tapeAlertDefs = [64]tapeAlertDef{
+	{"Read warning", TapeAlertWarning, "The tape drive is having problems reading data. No data has been lost, but there has been a reduction in the performance of the tape."},
+	{"Write warning", TapeAlertWarning, "The tape drive is having problems writing data. No data has been lost, but there has been a reduction in the capacity of the tape."},
+	{"Hard error", TapeAlertWarning, "The operation has stopped because an error has occurred while reading or writing data that the drive cannot correct."},
+	{"Media", TapeAlertCritical, "Your data is at risk: copy any data you require from this tape, do not use this tape again, restart the operation with a different tape."},
+	{"Read failure", TapeAlertCritical, "The tape is damaged or the drive is faulty. Call the tape drive supplier helpline."},
+	{"Write failure", TapeAlertCritical, "The tape is from a faulty batch or the tape drive is faulty: use a good tape to test the drive. If the problem persists, call the tape drive supplier helpline."},
+	{"Media life", TapeAlertWarning, "The tape cartridge has reached the end of its calculated useful life: copy any data you need to another tape, discard the old tape."},
+	{"Not data grade", TapeAlertWarning, "The tape cartridge is not data-grade. Any data you write to the tape is at risk. Replace the cartridge with a data-grade tape."},
+	{"Write protect", TapeAlertCritical, "You are trying to write to a write protected cartridge. Remove the write protection or use another tape."},
+	{"No removal", TapeAlertInfo, "You cannot eject the cartridge because the tape drive is in use. Wait until the operation is complete before ejecting the cartridge."},
+	{"Cleaning media", TapeAlertInfo, "The tape in the drive is a cleaning cartridge."},
+	{"Unsupported format", TapeAlertInfo, "You have tried to load a cartridge of a type which is not supported by this drive."},
+	{"Recoverable mechanical cartridge failure", TapeAlertCritical, "The operation has failed because the tape in the drive has experienced a mechanical failure: discard the old tape, restart the operation with a different tape."},
+	{"Unrecoverable mechanical cartridge failure", TapeAlertCritical, "The operation has failed because the tape in the drive has experienced a mechanical failure: do not attempt to extract the tape cartridge, call the tape drive supplier helpline."},
+	{"Memory chip in cartridge failure", TapeAlertWarning, "The memory in the tape cartridge has failed, which reduces performance. Do not use the cartridge for further write operations."},
+	{"Forced eject", TapeAlertCritical, "The operation has failed because the tape cartridge was manually de-mounted while the tape drive was actively writing or reading."},
+	{"Read only format", TapeAlertWarning, "You have loaded a cartridge of a type that is read-only in this drive. The cartridge will appear as write protected."},
+	{"Tape directory corrupted on load", TapeAlertWarning, "The tape directory on the tape cartridge has been corrupted. File search performance will be degraded. The tape directory can be rebuilt by reading all the data on the cartridge."},
+	{"Nearing media life", TapeAlertInfo, "The tape cartridge is nearing the end of its calculated life. It is recommended that you use another tape cartridge for your next backup, store this tape in a safe place in case you need to restore data from it."},
+	{"Clean now", TapeAlertCritical, "The tape drive needs cleaning: if the operation has stopped, eject the tape and clean the drive, if the operation has not stopped, wait for it to finish and then clean the drive."},
+	{"Clean periodic", TapeAlertWarning, "The tape drive is due for routine cleaning: wait for the current operation to finish, then use a cleaning cartridge."},
+	{"Expired cleaning media", TapeAlertCritical, "The last cleaning cartridge used in the tape drive has worn out: discard the worn out cleaning cartridge, wait for the current operation to finish, then use a new cleaning cartridge."},
+	{"Invalid cleaning tape", TapeAlertCritical, "The last cleaning cartridge used in the tape drive was an invalid type: do not use this cleaning cartridge in this drive, wait for the current operation to finish, then use a valid cleaning cartridge."},
+	{"Retension requested", TapeAlertWarning, "The tape drive has requested a retension operation."},
+	{"Dual-port interface error", TapeAlertWarning, "A redundant interface port on the tape drive has failed."},
+	{"Cooling fan failure", TapeAlertWarning, "A tape drive cooling fan has failed."},
+	{"Power supply failure", TapeAlertWarning, "A redundant power supply has failed inside the tape drive enclosure. Check the enclosure users manual for instructions on replacing the failed power supply."},
+	{"Power consumption", TapeAlertWarning, "The tape drive power consumption is outside the specified range."},
+	{"Drive maintenance", TapeAlertWarning, "Preventive maintenance of the tape drive is required. Check the tape drive users manual for device specific preventive maintenance tasks or call the tape drive supplier helpline."},
+	{"Hardware A", TapeAlertCritical, "The tape drive has a hardware fault: eject the tape or magazine, reset the drive, restart the operation."},
+	{"Hardware B", TapeAlertCritical, "The tape drive has a hardware fault: turn the tape drive off and then on again, restart the operation. If the problem persists, call the tape drive supplier helpline."},
+	{"Interface", TapeAlertWarning, "The tape drive has a problem with the application client interface: check the cables and cable connections, restart the operation."},
+	{"Eject media", TapeAlertCritical, "The operation has failed: eject the tape or magazine, reinsert the tape or magazine, restart the operation."},
+	{"Download fail", TapeAlertWarning, "The firmware download has failed because you have tried to use the incorrect firmware for this tape drive. Obtain the correct firmware and try again."},
+	{"Drive humidity", TapeAlertWarning, "Environmental conditions inside the tape drive are outside the specified humidity range."},
+	{"Drive temperature", TapeAlertWarning, "Environmental conditions inside the tape drive are outside the specified temperature range."},
+	{"Drive voltage", TapeAlertWarning, "The voltage supply to the tape drive is outside the specified range."},
+	{"Predictive failure", TapeAlertCritical, "A hardware failure of the tape drive is predicted. Call the tape drive supplier helpline."},
+	{"Diagnostics required", TapeAlertWarning, "The tape drive may have a hardware fault. Run extended diagnostics to verify and diagnose the problem. Check the tape drive users manual for device specific instructions on running extended diagnostic tests."},
+	{"Obsolete (loader hardware A)", TapeAlertInfo, ""},
+	{"Obsolete (loader stray tape)", TapeAlertInfo, ""},
+	{"Obsolete (loader hardware B)", TapeAlertInfo, ""},
+	{"Obsolete (loader door)", TapeAlertInfo, ""},
+	{"Obsolete (loader hardware C)", TapeAlertInfo, ""},
+	{"Obsolete (loader magazine)", TapeAlertInfo, ""},
+	{"Obsolete (loader predictive failure)", TapeAlertInfo, ""},
+	{"Reserved", TapeAlertInfo, ""},
+	{"Reserved", TapeAlertInfo, ""},
+	{"Diminished native capacity", TapeAlertInfo, "The tape cartridge has been formatted with a reduced native capacity."},
+	{"Lost statistics", TapeAlertWarning, "Media statistics have been lost at some time in the past."},
+	{"Tape directory invalid at unload", TapeAlertWarning, "The tape directory on the tape cartridge just unloaded has been corrupted. File search performance will be degraded. The tape directory can be rebuilt by reading all the data."},
+	{"Tape system area write failure", TapeAlertCritical, "The tape just unloaded could not write its system area successfully: copy data to another tape cartridge, discard the old cartridge."},
+	{"Tape system area read failure", TapeAlertCritical, "The tape system area could not be read successfully at load time: copy data to another tape cartridge."},
+	{"No start of data", TapeAlertCritical, "The start of data could not be found on the tape: check that you are using the correct format tape, discard the tape or return the tape to your supplier."},
+	{"Loading or threading failure", TapeAlertCritical, "The operation has failed because the media cannot be loaded and threaded. Remove the cartridge, inspect it as specified in the product manual, and retry the operation. If the problem persists, call the tape drive supplier help line."},
+	{"Unrecoverable unload failure", TapeAlertCritical, "The operation has failed because the medium cannot be unloaded: do not attempt to extract the tape cartridge, call the tape driver supplier help line."},
+	{"Automation interface failure", TapeAlertCritical, "The tape drive has a problem with the automation interface: check the power to the automation system, check the cables and cable connections, call the supplier help line if problem persists."},
+	{"Microcode failure", TapeAlertWarning, "The tape drive has reset itself due to a detected microcode fault. If problem persists, call the supplier help line."},
+	{"WORM medium - integrity check failed", TapeAlertWarning, "The tape drive has detected an inconsistency during the WORM medium integrity checks. Someone may have tampered with the cartridge."},
+	{"WORM medium - overwrite attempted", TapeAlertWarning, "An attempt had been made to overwrite user data on a WORM medium: if a WORM medium was used inadvertently, replace it with a normal data medium."},
+	{"Reserved", TapeAlertInfo, ""},
+	{"Reserved", TapeAlertInfo, ""},
+	{"Reserved", TapeAlertInfo, ""},
+	{"Reserved", TapeAlertInfo, ""},
+}
+
+// Decode returns all 64 flags with Active set from f
+func (f TapeAlertFlags) Decode() []TapeAlert {
+	alerts := make([]TapeAlert, len(tapeAlertDefs))
+	for i, def := range tapeAlertDefs {
+		alerts[i] = TapeAlert{
+			Flag:     i + 1,
+			Name:     def.name,
+			Severity: def.severity,
+			Action:   def.action,
+			Active:   f.Has(i + 1),
+		}
+	}
+	return alerts
+}
+
+// Active returns only the flags set in f
+func (f TapeAlertFlags) Active() []TapeAlert {
+	var alerts []TapeAlert
+	for _, a := range f.Decode() {
+		if a.Active {
+			alerts = append(alerts, a)
+		}
+	}
+	return alerts
+}
+
+// TapeAlerts reads LogPageTapeAlert and decodes all 64 flags.
+// Reading the page clears the flags on the drive.
+func (d Drive) TapeAlerts() ([]TapeAlert, error) {
+	f, err := d.TapeAlertFlags()
+	if err != nil {
+		return nil, err
+	}
+	return f.Decode(), nil
+}
+
+// LogTapeAlerts logs active TapeAlert flags and reports whether any of them is critical
+func (d Drive) LogTapeAlerts() (critical bool, err error) {
+	f, err := d.TapeAlertFlags()
+	if err != nil {
+		return false, err
+	}
+	for _, a := range f.Active() {
+		log.Println(a)
+		if a.Severity == TapeAlertCritical {
+			critical = true
+		}
+	}
+	return critical, nil
+}
@@ -0,0 +1,299 @@
+package tape
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// VirtualTape is a file-backed Device for developing without a drive.
+// Each partition is stored in dir as part<N>.vt, a sequence of records
+// of 1 byte kind, 4 bytes big-endian length and the block data.
+type VirtualTape struct {
+	dir   string
+	parts []*vtPart
+	part  int32
+}
+
+type vtPart struct {
+	f    *os.File
+	objs []vtObject
+	pos  uint64 // next object to read or write, len(objs) at EOD
+}
+
+type vtObject struct {
+	off      int64 // offset of record header
+	length   uint32
+	filemark bool
+}
+
+const (
+	vtKindBlock    = 'B'
+	vtKindFilemark = 'F'
+	vtHeaderSize   = 5
+)
+
+var (
+	vtEOD      = &SenseError{ResponseCode: 0x70, Key: SenseKeyBlankCheck, ASC: 0x00, ASCQ: 0x05}
+	vtBOP      = &SenseError{ResponseCode: 0x70, Key: SenseKeyNoSense, ASC: 0x00, ASCQ: 0x04, EOM: true}
+	vtFilemark = &SenseError{ResponseCode: 0x70, Key: SenseKeyNoSense, ASC: 0x00, ASCQ: 0x01, Filemark: true}
+)
+
+func vtPartPath(dir string, i int) string {
+	return filepath.Join(dir, fmt.Sprintf("part%d.vt", i))
+}
+
+// FormatVirtualTape creates an empty virtual tape with given partitions in dir,
+// erasing anything recorded before.
+func FormatVirtualTape(dir string, partitions int) error {
+	if partitions < 1 {
+		return errors.New("vtape: need at least 1 partition")
+	}
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return err
+	}
+	old, err := filepath.Glob(filepath.Join(dir, "part*.vt"))
+	if err != nil {
+		return err
+	}
+	for _, p := range old {
+		if err := os.Remove(p); err != nil {
+			return err
+		}
+	}
+	for i := range partitions {
+		if err := os.WriteFile(vtPartPath(dir, i), nil, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// OpenVirtualTape opens a virtual tape previously created by FormatVirtualTape.
+// Incomplete trailing records are treated as never written.
+func OpenVirtualTape(dir string) (*VirtualTape, error) {
+	vt := &VirtualTape{dir: dir}
+	for i := 0; ; i++ {
+		f, err := os.OpenFile(vtPartPath(dir, i), os.O_RDWR, 0)
+		if errors.Is(err, os.ErrNotExist) {
+			break
+		}
+		if err != nil {
+			vt.Close()
+			return nil, err
+		}
+		p := &vtPart{f: f}
+		vt.parts = append(vt.parts, p)
+		if err := p.scan(); err != nil {
+			vt.Close()
+			return nil, fmt.Errorf("vtape: partition %d: %w", i, err)
+		}
+	}
+	if len(vt.parts) == 0 {
+		return nil, fmt.Errorf("vtape: no partitions in %s", dir)
+	}
+	return vt, nil
+}
+
+func (p *vtPart) scan() error {
+	st, err := p.f.Stat()
+	if err != nil {
+		return err
+	}
+	var hdr [vtHeaderSize]byte
+	off := int64(0)
+	for off+vtHeaderSize <= st.Size() {
+		if _, err := p.f.ReadAt(hdr[:], off); err != nil {
+			return err
+		}
+		obj := vtObject{off: off, length: binary.BigEndian.Uint32(hdr[1:])}
+		switch hdr[0] {
+		case vtKindBlock:
+		case vtKindFilemark:
+			obj.filemark = true
+		default:
+			return fmt.Errorf("bad record kind %q at %d", hdr[0], off)
+		}
+		next := off + vtHeaderSize + int64(obj.length)
+		if next > st.Size() {
+			break
+		}
+		p.objs = append(p.objs, obj)
+		off = next
+	}
+	return p.f.Truncate(off)
+}
+
+func (vt *VirtualTape) cur() *vtPart { return vt.parts[vt.part] }
+
+// truncate drops everything from current position on, as writing does on a real tape
+func (p *vtPart) truncate() error {
+	if p.pos == uint64(len(p.objs)) {
+		return nil
+	}
+	err := p.f.Truncate(p.objs[p.pos].off)
+	if err != nil {
+		return err
+	}
+	p.objs = p.objs[:p.pos]
+	return nil
+}
+
+func (p *vtPart) end() int64 {
+	if len(p.objs) == 0 {
+		return 0
+	}
+	last := p.objs[len(p.objs)-1]
+	return last.off + vtHeaderSize + int64(last.length)
+}
+
+func (p *vtPart) append(kind byte, dat []byte) error {
+	if err := p.truncate(); err != nil {
+		return err
+	}
+	obj := vtObject{off: p.end(), length: uint32(len(dat)), filemark: kind == vtKindFilemark}
+	rec := make([]byte, vtHeaderSize+len(dat))
+	rec[0] = kind
+	binary.BigEndian.PutUint32(rec[1:], obj.length)
+	copy(rec[vtHeaderSize:], dat)
+	if _, err := p.f.WriteAt(rec, obj.off); err != nil {
+		return err
+	}
+	p.objs = append(p.objs, obj)
+	p.pos++
+	return nil
+}
+
+func (vt *VirtualTape) ReadBlock(buf []byte) (int, error) {
+	p := vt.cur()
+	if p.pos >= uint64(len(p.objs)) {
+		return 0, vtEOD
+	}
+	obj := p.objs[p.pos]
+	p.pos++
+	if obj.filemark {
+		return 0, io.EOF
+	}
+	n := min(len(buf), int(obj.length))
+	_, err := p.f.ReadAt(buf[:n], obj.off+vtHeaderSize)
+	if err != nil {
+		return 0, err
+	}
+	if n < int(obj.length) {
+		return n, io.ErrShortBuffer
+	}
+	return n, nil
+}
+
+func (vt *VirtualTape) WriteBlock(buf []byte) error {
+	return vt.cur().append(vtKindBlock, buf)
+}
+
+func (vt *VirtualTape) WriteFilemarks(count int32) error {
+	p := vt.cur()
+	if count == 0 {
+		// flush only, still ends the recorded data here
+		return p.truncate()
+	}
+	for range count {
+		if err := p.append(vtKindFilemark, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SpaceBlocks stops after (or before, when backward) a filemark and returns ErrFilemark
+func (vt *VirtualTape) SpaceBlocks(count int32) error {
+	p := vt.cur()
+	for ; count > 0; count-- {
+		if p.pos >= uint64(len(p.objs)) {
+			return vtEOD
+		}
+		p.pos++
+		if p.objs[p.pos-1].filemark {
+			return vtFilemark
+		}
+	}
+	for ; count < 0; count++ {
+		if p.pos == 0 {
+			return vtBOP
+		}
+		p.pos--
+		if p.objs[p.pos].filemark {
+			return vtFilemark
+		}
+	}
+	return nil
+}
+
+func (vt *VirtualTape) SpaceFilemarks(count int32) error {
+	p := vt.cur()
+	for count > 0 {
+		if p.pos >= uint64(len(p.objs)) {
+			return vtEOD
+		}
+		p.pos++
+		if p.objs[p.pos-1].filemark {
+			count--
+		}
+	}
+	for count < 0 {
+		if p.pos == 0 {
+			return vtBOP
+		}
+		p.pos--
+		if p.objs[p.pos].filemark {
+			count++
+		}
+	}
+	return nil
+}
+
+func (vt *VirtualTape) Locate(part int32, block uint64) error {
+	if err := vt.SwitchPartition(part); err != nil {
+		return err
+	}
+	p := vt.cur()
+	if block > uint64(len(p.objs)) {
+		p.pos = uint64(len(p.objs))
+		return vtEOD
+	}
+	p.pos = block
+	return nil
+}
+
+// ReadPosition reports the logical object number as Block, counting filemarks as the drive does
+func (vt *VirtualTape) ReadPosition() (Position, error) {
+	p := vt.cur()
+	pos := Position{Partition: uint32(vt.part), Block: p.pos, BOP: p.pos == 0}
+	for _, obj := range p.objs[:p.pos] {
+		if obj.filemark {
+			pos.File++
+		}
+	}
+	return pos, nil
+}
+
+// SwitchPartition returns to the position last visited in part, like the st driver does
+func (vt *VirtualTape) SwitchPartition(part int32) error {
+	if part < 0 || int(part) >= len(vt.parts) {
+		return fmt.Errorf("vtape: partition %d out of range", part)
+	}
+	vt.part = part
+	return nil
+}
+
+func (vt *VirtualTape) Close() error {
+	var err error
+	for _, p := range vt.parts {
+		if e := p.f.Close(); e != nil && err == nil {
+			err = e
+		}
+	}
+	return err
+}
@@ -0,0 +1,155 @@
+package tape
+
+import (
+	"errors"
+	"io"
+	"testing"
+)
+
+// errAny is expected for steps failing with any error
+var errAny = errors.New("any error")
+
+type vtStep struct {
+	op   string // write, filemarks, read, locate, switch, space, position, reopen
+	data string
+	n    int64
+	part int32
+	err  error
+	pos  Position
+}
+
+func TestVirtualTape(t *testing.T) {
+	tests := []struct {
+		name  string
+		parts int
+		steps []vtStep
+	}{
+		{"read write", 1, []vtStep{
+			{op: "write", data: "alpha"},
+			{op: "write", data: "beta"},
+			{op: "locate"},
+			{op: "read", data: "alpha"},
+			{op: "read", data: "beta"},
+			{op: "read", err: ErrEOD},
+		}},
+		{"filemarks", 1, []vtStep{
+			{op: "write", data: "a"},
+			{op: "filemarks", n: 1},
+			{op: "write", data: "b"},
+			{op: "filemarks", n: 2},
+			{op: "locate"},
+			{op: "read", data: "a"},
+			{op: "read", err: io.EOF},
+			{op: "read", data: "b"},
+			{op: "read", err: io.EOF},
+			{op: "read", err: io.EOF},
+			{op: "read", err: ErrEOD},
+			{op: "locate"},
+			{op: "space", n: 2},
+			{op: "position", pos: Position{Block: 4, File: 2}},
+			{op: "space", n: -1},
+			{op: "position", pos: Position{Block: 3, File: 1}},
+			{op: "space", n: -2, err: ErrBOP},
+		}},
+		{"locate counts filemarks", 1, []vtStep{
+			{op: "write", data: "a"},
+			{op: "filemarks", n: 1},
+			{op: "write", data: "b"},
+			{op: "write", data: "c"},
+			{op: "position", pos: Position{Block: 4, File: 1}},
+			{op: "locate", n: 2},
+			{op: "position", pos: Position{Block: 2, File: 1}},
+			{op: "read", data: "b"},
+			{op: "locate", n: 1},
+			{op: "read", err: io.EOF},
+			{op: "locate", n: 9, err: ErrEOD},
+			{op: "position", pos: Position{Block: 4, File: 1}},
+			{op: "locate", pos: Position{BOP: true}},
+			{op: "position", pos: Position{BOP: true}},
+		}},
+		{"partitions", 2, []vtStep{
+			{op: "write", data: "p0"},
+			{op: "switch", part: 1},
+			{op: "position", pos: Position{Partition: 1, BOP: true}},
+			{op: "write", data: "p1"},
+			{op: "switch", part: 0},
+			{op: "position", pos: Position{Block: 1}},
+			{op: "read", err: ErrEOD},
+			{op: "locate", part: 1},
+			{op: "read", data: "p1"},
+			{op: "switch", part: 2, err: errAny},
+		}},
+		{"write truncates at EOD", 1, []vtStep{
+			{op: "write", data: "a"},
+			{op: "write", data: "b"},
+			{op: "filemarks", n: 1},
+			{op: "locate", n: 1},
+			{op: "write", data: "c"},
+			{op: "reopen"},
+			{op: "locate"},
+			{op: "read", data: "a"},
+			{op: "read", data: "c"},
+			{op: "read", err: ErrEOD},
+			{op: "filemarks", n: 0},
+			{op: "locate", n: 1},
+			{op: "filemarks", n: 0},
+			{op: "reopen"},
+			{op: "locate"},
+			{op: "read", data: "a"},
+			{op: "read", err: ErrEOD},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := FormatVirtualTape(dir, tt.parts); err != nil {
+				t.Fatal(err)
+			}
+			vt, err := OpenVirtualTape(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer func() { vt.Close() }()
+			buf := make([]byte, 64)
+			for i, s := range tt.steps {
+				var err error
+				switch s.op {
+				case "write":
+					err = vt.WriteBlock([]byte(s.data))
+				case "filemarks":
+					err = vt.WriteFilemarks(int32(s.n))
+				case "read":
+					var n int
+					n, err = vt.ReadBlock(buf)
+					if got := string(buf[:n]); err == nil && got != s.data {
+						t.Fatalf("step %d: read %q, want %q", i, got, s.data)
+					}
+				case "locate":
+					err = vt.Locate(s.part, uint64(s.n))
+				case "switch":
+					err = vt.SwitchPartition(s.part)
+				case "space":
+					err = vt.SpaceFilemarks(int32(s.n))
+				case "position":
+					var pos Position
+					pos, err = vt.ReadPosition()
+					if err == nil && pos != s.pos {
+						t.Fatalf("step %d: position %v, want %v", i, pos, s.pos)
+					}
+				case "reopen":
+					if err = vt.Close(); err == nil {
+						vt, err = OpenVirtualTape(dir)
+					}
+				}
+				switch {
+				case s.err == nil && err != nil:
+					t.Fatalf("step %d %s: %v", i, s.op, err)
+				case s.err != nil && err == nil:
+					t.Fatalf("step %d %s: want error %v", i, s.op, s.err)
+				case s.err != nil && s.err != errAny && !errors.Is(err, s.err):
+					t.Fatalf("step %d %s: error %v, want %v", i, s.op, err, s.err)
+				}
+			}
+		})
+	}
+}
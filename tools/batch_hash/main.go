@@ -7,11 +7,17 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"hash/fnv"
 	"io"
 	"log"
 	"os"
 	"os/exec"
+	"os/signal"
+	"path/filepath"
 	"strconv"
+	"sync"
+	"sync/atomic"
+	"syscall"
 
 	"github.com/LXY1226/ltfswriter/tape"
 	"github.com/LXY1226/ltfswriter/utils"
@@ -21,6 +27,14 @@ import (
 
 type Task struct {
 	Tapes []string `json:"tapes"`
+	// VirtualDir reads tapes from <VirtualDir>/<tag> as tape.VirtualTape instead of the library
+	VirtualDir string `json:"virtual_dir,omitempty"`
+	// Key of Keystore decrypts encrypted tapes
+	Keystore string `json:"keystore,omitempty"`
+	Key      string `json:"key,omitempty"`
+	// ReservationKey reserves the drive for the job against other hosts, hex,
+	// derived from the host name if empty
+	ReservationKey string `json:"reservation_key,omitempty"`
 }
 
 func LoadJson[T any](path string) (*T, error) {
@@ -40,25 +54,146 @@ func LoadJson[T any](path string) (*T, error) {
 
 var sch = tape.NewMediaChanger("/dev/sch0")
 
+const driveID = 0
+
+// drivePath finds the device node of driveID by its serial number, guessing a node could
+// hash the tapes of another drive of the library
+func drivePath() (string, error) {
+	ld, err := sch.Drive(driveID)
+	if err != nil {
+		return "", fmt.Errorf("mapping drive: %w", err)
+	}
+	log.Println("drive", driveID, "is", ld.NstPath, "serial", ld.Serial)
+	return ld.NstPath, nil
+}
+
+func reservationKey(s string) (uint64, error) {
+	if s != "" {
+		return strconv.ParseUint(s, 16, 64)
+	}
+	host, err := os.Hostname()
+	if err != nil {
+		return 0, err
+	}
+	h := fnv.New64a()
+	h.Write([]byte(host))
+	return h.Sum64(), nil
+}
+
+// reservation keeps the drive reserved against the other hosts of the SAN for the whole job
+var reservation struct {
+	ctl  *tape.Drive // sg node of the drive, the st node is reopened for every tape
+	key  uint64
+	once sync.Once
+}
+
+func reserveDrive(path string, key uint64) {
+	ds, err := tape.Discover()
+	if err != nil {
+		log.Fatal(err)
+	}
+	dd, ok := ds.DriveByPath(path)
+	if !ok || dd.SgPath == "" {
+		log.Fatal("no sg node found for ", path, ", cannot reserve the drive")
+	}
+	ctl, err := tape.OpenWith(dd.SgPath, tape.OpenOptions{Write: true})
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := ctl.Reserve(key); err != nil {
+		ctl.Close()
+		log.Fatal(path, ": ", err)
+	}
+	log.Printf("drive %s reserved with key %#016x", path, key)
+	reservation.ctl, reservation.key = ctl, key
+}
+
+func releaseDrive() {
+	reservation.once.Do(func() {
+		if reservation.ctl == nil {
+			return
+		}
+		if err := reservation.ctl.Release(reservation.key); err != nil {
+			log.Println("releasing drive reservation:", err)
+		}
+		reservation.ctl.Close()
+	})
+}
+
+// medium is the lock of the tape being read, ejecting it mid-read corrupts the output
+var medium atomic.Pointer[tape.MediumLock]
+
+func unlockMedium() {
+	if l := medium.Swap(nil); l != nil {
+		if err := l.Unlock(); err != nil {
+			log.Println("unlocking medium:", err)
+		}
+	}
+}
+
+// releaseOnSignal unlocks the medium and releases the drive on SIGINT and SIGTERM before
+// dying of the signal, between tapes as well as while one is locked
+func releaseOnSignal() {
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		sig := <-c
+		log.Printf("%v: releasing drive", sig)
+		unlockMedium()
+		releaseDrive()
+		signal.Reset(sig)
+		syscall.Kill(os.Getpid(), sig.(syscall.Signal))
+	}()
+}
+
+// fatal unlocks the medium and releases the drive before exiting
+func fatal(v ...any) {
+	unlockMedium()
+	releaseDrive()
+	log.Fatal(v...)
+}
+
 func main() {
 	task, err := LoadJson[Task]("run.json")
 	if err != nil {
 		log.Fatal(err)
 	}
+	var ks *tape.Keystore
+	if task.Key != "" {
+		ks, err = tape.LoadKeystore(task.Keystore)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+	var path string
+	if task.VirtualDir == "" {
+		path, err = drivePath()
+		if err != nil {
+			log.Fatal(err)
+		}
+		key, err := reservationKey(task.ReservationKey)
+		if err != nil {
+			log.Fatal(err)
+		}
+		reserveDrive(path, key)
+		releaseOnSignal()
+		defer releaseDrive()
+		defer unlockMedium()
+	}
 	zstdCmd := exec.Command("zstd", "-d", "-v")
 	zstdOut, err := zstdCmd.StdoutPipe()
 	if err != nil {
-		log.Fatal(err)
+		fatal(err)
 	}
 	zstdIn, err := zstdCmd.StdinPipe()
 	if err != nil {
-		log.Fatal(err)
+		fatal(err)
 	}
 	zstdCmd.Stderr = os.Stderr
 	go func() {
 		err := zstdCmd.Run()
 		if err != nil {
-			log.Fatal(err)
+			fatal(err)
 		}
 	}()
 	// async zstd (channel)
@@ -66,7 +201,7 @@ func main() {
 	// async untar + hasher(blake3) + logger(buffered writer)
 	out, err := os.Create("run_out.tsv")
 	if err != nil {
-		log.Fatal(err)
+		fatal(err)
 	}
 	bufOut := bufio.NewWriter(out)
 	defer bufOut.Flush()
@@ -74,7 +209,7 @@ func main() {
 		for {
 			tarHeader, err := tarReader.Next()
 			if err != nil && err != tar.ErrInsecurePath {
-				panic(err)
+				fatal(err)
 			}
 			bufOut.Write([]byte(tarHeader.Name))
 			bufOut.WriteByte('\t')
@@ -82,7 +217,7 @@ func main() {
 			hasher := blake3.New()
 			n, err := io.CopyBuffer(hasher, tarReader, make([]byte, 1024*1024))
 			if err != nil && err != io.EOF {
-				panic(err)
+				fatal(err)
 			}
 			if n != tarHeader.Size {
 				log.Println("inconsistent size for tar:", n, tarHeader.Size)
@@ -94,15 +229,37 @@ func main() {
 	}()
 	for _, tapeTag := range task.Tapes {
 		log.Println("Ready for", tapeTag)
-		TryLoadByTag(tapeTag, 0)
-		// open drive
-		drive := EnsureOpenDrive(tapeTag, "/dev/st0")
+		var drive tape.Device
+		if task.VirtualDir != "" {
+			drive, err = tape.OpenVirtualTape(filepath.Join(task.VirtualDir, tapeTag))
+			if err != nil {
+				fatal(err)
+			}
+		} else {
+			TryLoadByTag(tapeTag, driveID)
+			// open drive
+			d := EnsureOpenDrive(tapeTag, path)
+			// EIO reading through st is a conflict only if the reservation is not ours
+			d.SetReservationKey(reservation.key)
+			if ks != nil {
+				if err := d.EnableDecryption(ks, task.Key); err != nil {
+					fatal(tapeTag, " setting key: ", err)
+				}
+			}
+			// the handler of the lock may kill the process first, release there too
+			lock, err := d.LockMedium(func(os.Signal) { releaseDrive() })
+			if err != nil {
+				fatal(tapeTag, " locking medium: ", err)
+			}
+			medium.Store(lock)
+			drive = d
+		}
 		//drive.MTSeek()
 		// read out (512KB block size) & drop to zstd
 		buf := directio.AlignedBlock(1024 * 1024)
 		written := int64(0)
 		for {
-			nr, er := drive.Read(buf)
+			nr, er := drive.ReadBlock(buf)
 			if nr > 0 {
 				//fmt.Println(nr)
 				nw, ew := zstdIn.Write(buf[0:nr])
@@ -124,26 +281,53 @@ func main() {
 			}
 			if er != nil {
 				if er != io.EOF {
-					panic(err)
+					fatal(tapeTag, " reading: ", er)
 				}
 				break
 			}
 		}
 		log.Println(tapeTag, "read", written, "bytes")
+		checkTapeAlerts(tapeTag, drive)
+		unlockMedium()
 		// close drive
 		drive.Close()
+		if task.VirtualDir != "" {
+			continue
+		}
 		// unload
-		sch.Unload(0)
+		sch.Unload(driveID)
+	}
+}
+
+// checkTapeAlerts reports TapeAlert flags raised while reading tag, before it gets unloaded
+func checkTapeAlerts(tag string, drive tape.Device) {
+	d, ok := drive.(*tape.Drive)
+	if !ok {
+		return
+	}
+	critical, err := d.LogTapeAlerts()
+	if err != nil {
+		log.Println(tag, "reading TapeAlert:", err)
+		return
+	}
+	if critical {
+		log.Println(tag, "raised critical TapeAlert flags, check drive and tape before next job")
 	}
 }
 
 func EnsureOpenDrive(tag string, drivePath string) *tape.Drive {
 	for {
-		drive, err := tape.Open(drivePath)
+		// rewinding node, the tape goes back to BOT for sch.Unload
+		drive, err := tape.OpenWith(drivePath, tape.OpenOptions{Rewind: tape.RewindOnClose})
 		if err != nil {
 			log.Println("Error opening drive:", err)
 			goto retry
 		}
+		if st, err := drive.State(); err == nil && !st.Loaded() {
+			log.Println("No tape in", drivePath+":", st)
+			drive.Close()
+			goto retry
+		}
 
 		err = drive.MTSetOptions(tape.MTSTBOOLEANS |
 			tape.MTSTBUFFERWRITES | tape.MTSTASYNCWRITES | tape.MTSTCANBSR | tape.MTSTCANPARTITIONS |
@@ -152,6 +336,12 @@ func EnsureOpenDrive(tag string, drivePath string) *tape.Drive {
 		if err != nil {
 			panic(err)
 		}
+		// a tape left in the drive by an earlier run may be anywhere, hash it from BOT
+		if err = drive.MTREW(); err != nil {
+			log.Println("Error rewinding", drivePath+":", err)
+			drive.Close()
+			goto retry
+		}
 		return drive
 	retry:
 		utils.WaitForEnter(fmt.Sprintln("Please change the tape manually for", tag, "into", drivePath))
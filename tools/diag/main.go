@@ -0,0 +1,216 @@
+// diag runs the drive self-test and collects a support archive with the drive dump,
+// firmware trace log, INQUIRY, log pages and TapeAlert flags, as vendors ask for with a
+// ticket. It refuses a drive in use, the self-test and the dump would disturb the job.
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"syscall"
+	"time"
+
+	"github.com/LXY1226/ltfswriter/tape"
+)
+
+var (
+	out      = flag.String("o", "", "support archive to write, tape_diag_<serial>_<time>.tar.gz if empty")
+	bufferID = flag.Int("buffer", tape.DumpBufferIBMLTO, "READ BUFFER id of the drive dump")
+	noDump   = flag.Bool("nodump", false, "skip the drive dump")
+)
+
+// archive is a tar.gz being written, failed sections are noted in summary.txt
+type archive struct {
+	tw      *tar.Writer
+	summary bytes.Buffer
+	now     time.Time
+}
+
+func (a *archive) add(name string, dat []byte) {
+	err := a.tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0o644,
+		Size:    int64(len(dat)),
+		ModTime: a.now,
+	})
+	if err == nil {
+		_, err = a.tw.Write(dat)
+	}
+	if err != nil {
+		log.Fatal(name, ": ", err)
+	}
+}
+
+// addFrom adds what fill writes as name, spooled to a temporary file as the tar header
+// comes first with the size. Nothing is added if fill fails.
+func (a *archive) addFrom(name string, fill func(io.Writer) (int64, error)) (int64, error) {
+	tmp, err := os.CreateTemp("", "diag_*")
+	if err != nil {
+		return 0, err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+	n, err := fill(tmp)
+	if err != nil {
+		return n, err
+	}
+	if _, err = tmp.Seek(0, io.SeekStart); err != nil {
+		return n, err
+	}
+	err = a.tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0o644,
+		Size:    n,
+		ModTime: a.now,
+	})
+	if err == nil {
+		_, err = io.Copy(a.tw, tmp)
+	}
+	if err != nil {
+		log.Fatal(name, ": ", err)
+	}
+	return n, nil
+}
+
+// note records the outcome of a section to summary.txt and the log
+func (a *archive) note(section string, err error) {
+	line := section + ": ok"
+	if err != nil {
+		line = section + ": " + err.Error()
+	}
+	log.Println(line)
+	a.summary.WriteString(line + "\n")
+}
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: diag [flags] <device node, serial or H:C:T:L>")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	path := flag.Arg(0)
+	var nst string
+	if ds, err := tape.Discover(); err == nil {
+		if p, ok := ds.Resolve(path); ok {
+			path = p
+		}
+		if dd, ok := ds.DriveByPath(path); ok && dd.SgPath != "" {
+			path, nst = dd.SgPath, dd.NstPath
+		}
+	}
+	if nst != "" {
+		// st allows one open at a time, holding it keeps jobs off the drive meanwhile
+		hold, err := os.OpenFile(nst, os.O_RDONLY|syscall.O_NONBLOCK, 0)
+		if errors.Is(err, syscall.EBUSY) {
+			log.Fatal(nst, ": drive in use")
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer hold.Close()
+	}
+	drive, err := tape.OpenWith(path, tape.OpenOptions{Write: true})
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer drive.Close()
+	if r, err := drive.ReadReservations(); err == nil && r.Reserved {
+		log.Fatalf("%s: drive reserved with key %#016x, in use by another host", path, r.Key)
+	}
+
+	a := &archive{now: time.Now()}
+	var info bytes.Buffer
+	inq, err := drive.Inquiry()
+	if err == nil {
+		fmt.Fprintln(&info, inq)
+		fmt.Fprintf(&info, "vendor specific: %s\n", inq.VendorSpecific)
+		info.WriteString(hex.Dump(inq.Raw))
+	}
+	a.note("inquiry", err)
+	serial, err := drive.SerialNumber()
+	if err == nil {
+		fmt.Fprintln(&info, "serial:", serial)
+	}
+	if fds, err := drive.FirmwareDesignations(); err == nil {
+		for _, fd := range fds {
+			fmt.Fprintf(&info, "firmware VPD %#02x: %s\n", fd.Page, fd.Text)
+		}
+	}
+
+	if *out == "" {
+		*out = fmt.Sprintf("tape_diag_%s_%s.tar.gz", serial, a.now.Format("20060102_150405"))
+	}
+	f, err := os.Create(*out)
+	if err != nil {
+		log.Fatal(err)
+	}
+	zw := gzip.NewWriter(f)
+	a.tw = tar.NewWriter(zw)
+	a.add("inquiry.txt", info.Bytes())
+
+	log.Println("running self-test")
+	err = drive.SelfTest()
+	a.note("self-test", err)
+	result := "passed\n"
+	if err != nil {
+		result = "failed: " + err.Error() + "\n"
+	}
+	a.add("selftest.txt", []byte(result))
+
+	// read once, TapeAlert flags are cleared by reading their page
+	var logs, alerts bytes.Buffer
+	pages, err := drive.SupportedLogPages()
+	a.note("log pages", err)
+	for _, page := range pages {
+		lp, err := drive.LogSense(page, 0, tape.LogPCCurrentCumulative)
+		if err != nil {
+			fmt.Fprintf(&logs, "page %#02x: %v\n", page, err)
+			continue
+		}
+		fmt.Fprintf(&logs, "page %#02x\n", page)
+		for _, p := range lp.Params {
+			fmt.Fprintf(&logs, "\t%#04x ctl=%02x %x\n", p.Code, p.Control, p.Value)
+		}
+		if page == tape.LogPageTapeAlert {
+			for _, ta := range tape.ParseTapeAlertLog(lp).Active() {
+				fmt.Fprintln(&alerts, ta)
+			}
+		}
+	}
+	a.add("logpages.txt", logs.Bytes())
+	a.add("tapealerts.txt", alerts.Bytes())
+
+	n, err := a.addFrom("firmware_trace.bin", drive.ReadFirmwareTraceLog)
+	a.note(fmt.Sprintf("firmware trace log (%d bytes)", n), err)
+
+	if !*noDump {
+		log.Println("reading drive dump")
+		n, err := a.addFrom("dump.bin", func(w io.Writer) (int64, error) {
+			return drive.ReadBufferTo(w, byte(*bufferID))
+		})
+		a.note(fmt.Sprintf("drive dump (buffer %#02x, %d bytes)", *bufferID, n), err)
+	}
+
+	a.add("summary.txt", a.summary.Bytes())
+	if err := a.tw.Close(); err != nil {
+		log.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		log.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		log.Fatal(err)
+	}
+	log.Println("support archive written to", *out)
+}
@@ -0,0 +1,44 @@
+// force_eject frees a cartridge stuck in a drive, for administrators only
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/LXY1226/ltfswriter/tape"
+	"github.com/LXY1226/ltfswriter/utils"
+)
+
+func main() {
+	if len(os.Args) != 2 {
+		fmt.Fprintln(os.Stderr, "usage: force_eject <device node, serial or H:C:T:L>")
+		os.Exit(2)
+	}
+	if os.Geteuid() != 0 {
+		log.Fatal("force_eject must be run as root")
+	}
+	path := os.Args[1]
+	if ds, err := tape.Discover(); err == nil {
+		if p, ok := ds.Resolve(path); ok {
+			path = p
+		}
+		// the st node may still be held open by the stuck job
+		if dd, ok := ds.DriveByPath(path); ok && dd.SgPath != "" {
+			path = dd.SgPath
+		}
+	}
+	drive, err := tape.OpenWith(path, tape.OpenOptions{Write: true})
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer drive.Close()
+	if r, err := drive.ReadReservations(); err == nil && r.Reserved {
+		log.Printf("drive is reserved by key %#016x, the eject may be refused", r.Key)
+	}
+	utils.WaitForEnter(fmt.Sprintln("Forced eject of", path, "loses data not yet written to tape. Enter to continue, Ctrl-C to abort"))
+	if err := drive.ForcedEject(); err != nil {
+		log.Fatal(err)
+	}
+	log.Println(path, "ejected")
+}